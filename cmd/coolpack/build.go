@@ -1,7 +1,6 @@
 package coolpack
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,26 +8,21 @@ import (
 	"strings"
 
 	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/config"
 	"github.com/coollabsio/coolpack/pkg/detector"
 	"github.com/coollabsio/coolpack/pkg/generator"
 	"github.com/spf13/cobra"
 )
 
 var (
-	buildPath         string
-	buildImageName    string
-	buildTag          string
-	buildNoCache      bool
-	buildBuildEnvs    []string
-	buildInstallCmd   string
-	buildBuildCmd     string
-	buildStartCmd     string
-	buildStaticServer string
-	buildOutputDir    string
-	buildSPA          bool
-	buildNoSPA        bool
-	buildPackages     []string
-	buildPlanFile     string
+	buildPath      string
+	buildImageName string
+	buildTag       string
+	buildNoCache   bool
+	buildFlags     config.Flags
+	buildPlanFile  string
+	buildProvider  string
+	buildApp       string
 )
 
 var buildCmd = &cobra.Command{
@@ -41,16 +35,24 @@ in .coolpack/, and then builds the container image.
 If a coolpack.json file exists in the project root, it will be used
 instead of running detection. Use --plan to specify a different file.
 
+Settings in coolpack.toml override the detected plan (or the plan file).
+Flags win over environment variables, which win over coolpack.toml.
+
 Environment Variables:
   COOLPACK_INSTALL_CMD     Override install command
   COOLPACK_BUILD_CMD       Override build command
   COOLPACK_START_CMD       Override start command
   COOLPACK_BASE_IMAGE      Override base Docker image (e.g., node:20)
+  COOLPACK_BASE_VARIANT    Base image variant: debian-slim (default), alpine, distroless
   COOLPACK_NODE_VERSION    Override Node.js version
+  COOLPACK_NODE_RUNNER     Node.js server runner: node (default), distroless
   COOLPACK_STATIC_SERVER   Static file server: caddy (default), nginx
   COOLPACK_SPA_OUTPUT_DIR  Override static output directory (e.g., dist, build)
   COOLPACK_SPA             Enable SPA mode (serves index.html for all routes)
   COOLPACK_PACKAGES        Additional APT packages (comma-separated)
+  COOLPACK_PROCESS         Procfile process type to start (e.g., worker)
+  COOLPACK_PROVIDER        Force a provider instead of the highest ranked one
+  COOLPACK_APP             Monorepo app to build (e.g., apps/web)
 
 Build-time env vars (--build-env) are available during build (e.g., for
 Next.js NEXT_PUBLIC_*, Vite VITE_*, SvelteKit $env/static/*).
@@ -66,16 +68,10 @@ func init() {
 	buildCmd.Flags().StringVarP(&buildImageName, "name", "n", "", "Image name (defaults to directory name)")
 	buildCmd.Flags().StringVarP(&buildTag, "tag", "t", "latest", "Image tag")
 	buildCmd.Flags().BoolVar(&buildNoCache, "no-cache", false, "Build without cache")
-	buildCmd.Flags().StringArrayVar(&buildBuildEnvs, "build-env", nil, "Build-time environment variables (KEY=value or KEY to use current env)")
-	buildCmd.Flags().StringVarP(&buildInstallCmd, "install-cmd", "i", "", "Override install command")
-	buildCmd.Flags().StringVarP(&buildBuildCmd, "build-cmd", "b", "", "Override build command")
-	buildCmd.Flags().StringVarP(&buildStartCmd, "start-cmd", "s", "", "Override start command")
-	buildCmd.Flags().StringVar(&buildStaticServer, "static-server", "", "Static file server: caddy (default), nginx")
-	buildCmd.Flags().StringVar(&buildOutputDir, "output-dir", "", "Override static output directory (e.g., dist, build, out)")
-	buildCmd.Flags().BoolVar(&buildSPA, "spa", false, "Enable SPA mode (serves index.html for all routes)")
-	buildCmd.Flags().BoolVar(&buildNoSPA, "no-spa", false, "Disable SPA mode (overrides auto-detection)")
-	buildCmd.Flags().StringArrayVar(&buildPackages, "packages", nil, "Additional APT packages to install (e.g., curl, wget)")
+	addOverrideFlags(buildCmd, &buildFlags)
 	buildCmd.Flags().StringVar(&buildPlanFile, "plan", "", "Use plan file instead of detection (e.g., coolpack.json)")
+	buildCmd.Flags().StringVar(&buildProvider, "provider", "", "Force a provider instead of the highest ranked one (e.g., node)")
+	buildCmd.Flags().StringVar(&buildApp, "app", "", "Monorepo app to build, by path or package name (e.g., apps/web)")
 }
 
 func runBuild(cmd *cobra.Command, args []string) error {
@@ -134,6 +130,8 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		// Run detection
 		fmt.Println("Detecting application...")
 		d := detector.New(absPath)
+		d.SetProvider(buildProvider)
+		d.SetApp(buildApp)
 		plan, err = d.Detect()
 		if err != nil {
 			return fmt.Errorf("detection failed: %w", err)
@@ -144,20 +142,10 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Apply command overrides (CLI > env > detected)
-	applyCommandOverrides(plan, buildInstallCmd, buildBuildCmd, buildStartCmd)
-
-	// Apply static server setting (CLI > env > default)
-	applyStaticServerSetting(plan, buildStaticServer)
-
-	// Apply SPA setting (CLI > env > auto-detected)
-	applySPASetting(plan, buildSPA, buildNoSPA)
-
-	// Apply output directory override (CLI > env > framework default)
-	applyOutputDirSetting(plan, buildOutputDir)
-
-	// Apply custom packages (CLI > env > detected)
-	applyCustomPackagesBuild(plan, buildPackages)
+	// Apply overrides (CLI > env > coolpack.toml > detected)
+	if err := applyOverrides(plan, absPath, buildFlags); err != nil {
+		return err
+	}
 
 	// Print detection summary
 	framework := plan.Framework
@@ -173,39 +161,40 @@ func runBuild(cmd *cobra.Command, args []string) error {
 		fmt.Printf(" (%s%s)", plan.PackageManager, pmVersion)
 	}
 	// Print output type and SPA mode
-	if ot, ok := plan.Metadata["output_type"].(string); ok {
+	if ot := plan.Runtime.OutputType; ot != "" {
 		fmt.Printf(" [%s", ot)
-		if isSPA, ok := plan.Metadata["is_spa"].(bool); ok && isSPA {
+		if plan.Static.SPA {
 			fmt.Printf("/spa")
 		}
 		fmt.Printf("]")
 	}
 	fmt.Println()
 
-	// Parse build environment variables
-	envMap := parseEnvVars(buildBuildEnvs)
-	if len(envMap) > 0 {
-		plan.BuildEnv = envMap
-	}
-
-	// Create .coolpack directory
-	coolpackDir := filepath.Join(absPath, ".coolpack")
-	if err := os.MkdirAll(coolpackDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .coolpack directory: %w", err)
-	}
+	var dockerfilePath string
+	if existing := plan.Dockerfile.File; existing != "" && plan.Provider == "dockerfile" {
+		// Build the project's own Dockerfile as-is
+		fmt.Printf("Using existing Dockerfile: %s\n", existing)
+		dockerfilePath = filepath.Join(absPath, existing)
+	} else {
+		// Create .coolpack directory
+		coolpackDir := filepath.Join(absPath, ".coolpack")
+		if err := os.MkdirAll(coolpackDir, 0755); err != nil {
+			return fmt.Errorf("failed to create .coolpack directory: %w", err)
+		}
 
-	// Generate Dockerfile
-	fmt.Println("Generating Dockerfile...")
-	gen := generator.New(plan)
-	dockerfile, err := gen.GenerateDockerfile()
-	if err != nil {
-		return fmt.Errorf("failed to generate Dockerfile: %w", err)
-	}
+		// Generate Dockerfile
+		fmt.Println("Generating Dockerfile...")
+		gen := generator.New(plan)
+		dockerfile, err := gen.GenerateDockerfile()
+		if err != nil {
+			return fmt.Errorf("failed to generate Dockerfile: %w", err)
+		}
 
-	// Write Dockerfile
-	dockerfilePath := filepath.Join(coolpackDir, "Dockerfile")
-	if err := os.WriteFile(dockerfilePath, []byte(dockerfile), 0644); err != nil {
-		return fmt.Errorf("failed to write Dockerfile: %w", err)
+		// Write Dockerfile
+		dockerfilePath = filepath.Join(coolpackDir, "Dockerfile")
+		if err := os.WriteFile(dockerfilePath, []byte(dockerfile), 0644); err != nil {
+			return fmt.Errorf("failed to write Dockerfile: %w", err)
+		}
 	}
 
 	// Build Docker image
@@ -221,7 +210,7 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	}
 
 	// Add build args for environment variables
-	for key, value := range envMap {
+	for key, value := range plan.BuildEnv {
 		dockerArgs = append(dockerArgs, "--build-arg", fmt.Sprintf("%s=%s", key, value))
 	}
 
@@ -241,13 +230,16 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	// Show correct port based on output type
 	port := "3000"
 	outputType := "server"
-	if ot, ok := plan.Metadata["output_type"].(string); ok && ot == "static" {
+	if plan.Runtime.OutputType == "static" {
 		port = "80"
 		outputType = "static"
 	}
+	if p := plan.Runtime.Port; p != "" {
+		port = p
+	}
 
 	// Show output type and SPA mode
-	if isSPA, ok := plan.Metadata["is_spa"].(bool); ok && isSPA {
+	if plan.Static.SPA {
 		fmt.Printf("Output: %s (SPA mode enabled)\n", outputType)
 	} else {
 		fmt.Printf("Output: %s\n", outputType)
@@ -258,167 +250,3 @@ func runBuild(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
-
-// parseEnvVars parses environment variable arguments
-// Supports KEY=value format or KEY (pulls from current environment)
-func parseEnvVars(envArgs []string) map[string]string {
-	result := make(map[string]string)
-	for _, env := range envArgs {
-		if idx := strings.Index(env, "="); idx != -1 {
-			// KEY=value format
-			key := env[:idx]
-			value := env[idx+1:]
-			result[key] = value
-		} else {
-			// KEY only - pull from current environment
-			if value, exists := os.LookupEnv(env); exists {
-				result[env] = value
-			}
-		}
-	}
-	return result
-}
-
-// applyCommandOverrides applies command overrides from CLI flags or env vars
-// Priority: CLI flags > Environment variables > Auto-detected
-func applyCommandOverrides(plan *detector.Plan, installCmd, buildCmd, startCmd string) {
-	// Install command: CLI > env > detected
-	if installCmd != "" {
-		plan.InstallCommand = installCmd
-	} else if env := os.Getenv("COOLPACK_INSTALL_CMD"); env != "" {
-		plan.InstallCommand = env
-	}
-
-	// Build command: CLI > env > detected
-	if buildCmd != "" {
-		plan.BuildCommand = buildCmd
-	} else if env := os.Getenv("COOLPACK_BUILD_CMD"); env != "" {
-		plan.BuildCommand = env
-	}
-
-	// Start command: CLI > env > detected
-	if startCmd != "" {
-		plan.StartCommand = startCmd
-	} else if env := os.Getenv("COOLPACK_START_CMD"); env != "" {
-		plan.StartCommand = env
-	}
-}
-
-// applyStaticServerSetting applies static server setting from CLI or env var
-// Priority: CLI flag > Environment variable > default (caddy)
-func applyStaticServerSetting(plan *detector.Plan, staticServer string) {
-	if plan.Metadata == nil {
-		plan.Metadata = make(map[string]interface{})
-	}
-
-	if staticServer != "" {
-		plan.Metadata["static_server"] = staticServer
-	} else if env := os.Getenv("COOLPACK_STATIC_SERVER"); env != "" {
-		plan.Metadata["static_server"] = env
-	}
-	// Default is "caddy" which is handled in generator
-}
-
-// applySPASetting applies SPA setting from CLI or env var
-// Priority: --no-spa/COOLPACK_NO_SPA > --spa/COOLPACK_SPA > auto-detected
-func applySPASetting(plan *detector.Plan, spa bool, noSPA bool) {
-	if plan.Metadata == nil {
-		plan.Metadata = make(map[string]interface{})
-	}
-
-	// --no-spa and COOLPACK_NO_SPA take highest priority
-	if noSPA {
-		delete(plan.Metadata, "is_spa")
-		return
-	}
-	if env := os.Getenv("COOLPACK_NO_SPA"); env == "true" || env == "1" {
-		delete(plan.Metadata, "is_spa")
-		return
-	}
-
-	if spa {
-		plan.Metadata["is_spa"] = true
-	} else if env := os.Getenv("COOLPACK_SPA"); env == "true" || env == "1" {
-		plan.Metadata["is_spa"] = true
-	}
-	// Auto-detected value is already in metadata from provider
-}
-
-// applyOutputDirSetting applies output directory override from CLI or env var
-// Priority: CLI flag > Environment variable > framework default (handled in generator)
-func applyOutputDirSetting(plan *detector.Plan, outputDir string) {
-	if plan.Metadata == nil {
-		plan.Metadata = make(map[string]interface{})
-	}
-
-	if outputDir != "" {
-		plan.Metadata["output_dir_override"] = outputDir
-	} else if env := os.Getenv("COOLPACK_SPA_OUTPUT_DIR"); env != "" {
-		plan.Metadata["output_dir_override"] = env
-	}
-}
-
-// applyCustomPackagesBuild adds custom APT packages to the plan (merges with existing)
-func applyCustomPackagesBuild(plan *detector.Plan, packages []string) {
-	if plan.Metadata == nil {
-		plan.Metadata = make(map[string]interface{})
-	}
-
-	// Start with existing custom packages from plan file
-	var customPackages []string
-	if existing, ok := plan.Metadata["custom_packages"].([]interface{}); ok {
-		for _, pkg := range existing {
-			if s, ok := pkg.(string); ok {
-				customPackages = append(customPackages, s)
-			}
-		}
-	} else if existing, ok := plan.Metadata["custom_packages"].([]string); ok {
-		customPackages = append(customPackages, existing...)
-	}
-
-	// Add CLI packages
-	if len(packages) > 0 {
-		customPackages = append(customPackages, packages...)
-	}
-
-	// Add environment variable packages (comma-separated)
-	if env := os.Getenv("COOLPACK_PACKAGES"); env != "" {
-		for _, pkg := range strings.Split(env, ",") {
-			pkg = strings.TrimSpace(pkg)
-			if pkg != "" {
-				customPackages = append(customPackages, pkg)
-			}
-		}
-	}
-
-	if len(customPackages) == 0 {
-		return
-	}
-
-	// Deduplicate
-	seen := make(map[string]bool)
-	unique := make([]string, 0, len(customPackages))
-	for _, pkg := range customPackages {
-		if !seen[pkg] {
-			seen[pkg] = true
-			unique = append(unique, pkg)
-		}
-	}
-
-	plan.Metadata["custom_packages"] = unique
-}
-
-// loadPlanFromFile loads a build plan from a JSON file
-func loadPlanFromFile(path string) (*app.Plan, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
-
-	var plan app.Plan
-	if err := json.Unmarshal(data, &plan); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
-	}
-
-	return &plan, nil
-}
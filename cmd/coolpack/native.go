@@ -0,0 +1,90 @@
+package coolpack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+	"github.com/spf13/cobra"
+)
+
+var nativeListJSON bool
+
+var nativeCmd = &cobra.Command{
+	Use:   "native",
+	Short: "Inspect the Node.js native dependency registry",
+}
+
+var nativeListCmd = &cobra.Command{
+	Use:   "list [path]",
+	Short: "List the native dependencies and the APT packages they need",
+	Long: `List the native dependency registry used for the application at the given path
+(or current directory): the entries of its coolpack.native.json, then of
+~/.config/coolpack/native.json, then the built-ins.
+
+Entries are listed in lookup order. The first entry of a package whose version
+range matches the resolved version is used, so files extend and override the
+built-ins.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runNativeList,
+}
+
+func init() {
+	nativeListCmd.Flags().BoolVar(&nativeListJSON, "json", false, "Output the registry as JSON")
+	nativeCmd.AddCommand(nativeListCmd)
+}
+
+func runNativeList(cmd *cobra.Command, args []string) error {
+	path := "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	registry, err := node.LoadNativeRegistry(absPath)
+	if err != nil {
+		return err
+	}
+
+	if nativeListJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(registry)
+	}
+
+	fmt.Println("=== Native Dependency Registry ===")
+	fmt.Println()
+	for _, dep := range registry {
+		name := dep.Package
+		if dep.Versions != "" {
+			name = fmt.Sprintf("%s %s", name, dep.Versions)
+		}
+		description := dep.Description
+		if dep.GlibcOnly {
+			description = strings.TrimSpace(description + " (glibc only)")
+		}
+		fmt.Printf("  %-32s %s\n", name, description)
+		fmt.Printf("    build:   %s\n", packageList(dep.AptPackages))
+		fmt.Printf("    runtime: %s\n", packageList(dep.RuntimePackages))
+		if dep.Source != "" {
+			fmt.Printf("    source:  %s\n", dep.Source)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+// packageList joins APT packages for display
+func packageList(packages []string) string {
+	if len(packages) == 0 {
+		return "-"
+	}
+	return strings.Join(packages, ", ")
+}
@@ -0,0 +1,56 @@
+package coolpack
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/config"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+	"github.com/spf13/cobra"
+)
+
+// addOverrideFlags registers the flags that override plan settings
+func addOverrideFlags(cmd *cobra.Command, flags *config.Flags) {
+	cmd.Flags().StringArrayVar(&flags.BuildEnv, "build-env", nil, "Build-time environment variables (KEY=value or KEY to use current env)")
+	cmd.Flags().StringVarP(&flags.InstallCommand, "install-cmd", "i", "", "Override install command")
+	cmd.Flags().StringVarP(&flags.BuildCommand, "build-cmd", "b", "", "Override build command")
+	cmd.Flags().StringVarP(&flags.StartCommand, "start-cmd", "s", "", "Override start command")
+	cmd.Flags().StringVar(&flags.BaseVariant, "base-variant", "", "Base image variant: debian-slim (default), alpine, distroless")
+	cmd.Flags().StringVar(&flags.StaticServer, "static-server", "", "Static file server: caddy (default), nginx")
+	cmd.Flags().StringVar(&flags.OutputDir, "output-dir", "", "Override static output directory (e.g., dist, build, out)")
+	cmd.Flags().BoolVar(&flags.SPA, "spa", false, "Enable SPA mode (serves index.html for all routes)")
+	cmd.Flags().BoolVar(&flags.NoSPA, "no-spa", false, "Disable SPA mode (overrides auto-detection)")
+	cmd.Flags().StringArrayVar(&flags.Packages, "packages", nil, "Additional APT packages to install (e.g., curl, wget)")
+	cmd.Flags().StringVar(&flags.Process, "process", "", "Procfile process type to start (e.g., worker)")
+}
+
+// applyOverrides layers the flags, COOLPACK_* env vars and the project's coolpack.toml over the plan
+// Priority: CLI flags > Environment variables > coolpack.toml > detected (or plan file)
+func applyOverrides(plan *app.Plan, path string, flags config.Flags) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := config.Apply(plan, flags, cfg); err != nil {
+		return err
+	}
+	// The distroless runner starts node directly, whichever layer set the start command
+	if err := node.ResolveStartCommand(plan, path); err != nil {
+		return err
+	}
+	for _, warning := range plan.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return nil
+}
+
+// loadPlanFromFile loads a build plan from a JSON file, upgrading older schema versions
+func loadPlanFromFile(path string) (*app.Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return app.LoadPlan(data)
+}
@@ -8,7 +8,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/config"
 	"github.com/coollabsio/coolpack/pkg/detector"
+	"github.com/coollabsio/coolpack/pkg/providers/dockerfile"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
 	"github.com/spf13/cobra"
 )
 
@@ -16,8 +20,12 @@ var (
 	planOutputJSON bool
 	planPath       string
 	planOutFile    string
-	planPackages   []string
-	planBuildEnvs  []string
+	planFlags      config.Flags
+	planAll        bool
+	planProvider   string
+	planApp        string
+	planListApps   bool
+	planExplain    bool
 )
 
 var planCmd = &cobra.Command{
@@ -26,9 +34,21 @@ var planCmd = &cobra.Command{
 	Long: `Analyze the application at the given path (or current directory),
 detect the language, framework, and package manager, then output a build plan.
 
+Every provider scores how confident it is that it can build the application,
+and the highest ranked one is used. Use --all to see every candidate and
+--provider to force one. Use --explain to see where every field came from
+(a file, an environment variable, a flag or a framework default).
+
+Settings in coolpack.toml override the detected plan, and the override
+environment variables (e.g., COOLPACK_START_CMD) win over coolpack.toml.
+
 Environment Variables:
   COOLPACK_BASE_IMAGE      Override base Docker image
-  COOLPACK_NODE_VERSION    Override Node.js version`,
+  COOLPACK_BASE_VARIANT    Base image variant: debian-slim (default), alpine, distroless
+  COOLPACK_NODE_VERSION    Override Node.js version
+  COOLPACK_NODE_RUNNER     Node.js server runner: node (default), distroless
+  COOLPACK_PROVIDER        Force a provider instead of the highest ranked one
+  COOLPACK_APP             Monorepo app to plan (e.g., apps/web)`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runPlan,
 }
@@ -38,8 +58,14 @@ func init() {
 	planCmd.Flags().StringVarP(&planPath, "path", "p", "", "Path to the application (defaults to current directory)")
 	planCmd.Flags().StringVarP(&planOutFile, "out", "o", "", "Write plan to file (default: coolpack.json if flag used without value)")
 	planCmd.Flags().Lookup("out").NoOptDefVal = "coolpack.json"
-	planCmd.Flags().StringArrayVar(&planPackages, "packages", nil, "Additional APT packages to install (e.g., curl, wget)")
-	planCmd.Flags().StringArrayVar(&planBuildEnvs, "build-env", nil, "Build-time environment variables (KEY=value or KEY to use current env)")
+	planCmd.Flags().StringArrayVar(&planFlags.Packages, "packages", nil, "Additional APT packages to install (e.g., curl, wget)")
+	planCmd.Flags().StringArrayVar(&planFlags.BuildEnv, "build-env", nil, "Build-time environment variables (KEY=value or KEY to use current env)")
+	planCmd.Flags().StringVar(&planFlags.BaseVariant, "base-variant", "", "Base image variant: debian-slim (default), alpine, distroless")
+	planCmd.Flags().BoolVar(&planAll, "all", false, "Show every provider that matched, ranked by confidence")
+	planCmd.Flags().StringVar(&planProvider, "provider", "", "Force a provider instead of the highest ranked one (e.g., node)")
+	planCmd.Flags().StringVar(&planApp, "app", "", "Monorepo app to plan, by path or package name (e.g., apps/web)")
+	planCmd.Flags().BoolVar(&planListApps, "list-apps", false, "List the deployable apps of a monorepo")
+	planCmd.Flags().BoolVar(&planExplain, "explain", false, "Show where every plan field came from")
 }
 
 func runPlan(cmd *cobra.Command, args []string) error {
@@ -65,25 +91,35 @@ func runPlan(cmd *cobra.Command, args []string) error {
 
 	// Run detection
 	d := detector.New(absPath)
+	d.SetProvider(planProvider)
+	d.SetApp(planApp)
+
+	if planListApps {
+		return listApps(d)
+	}
+
 	plan, err := d.Detect()
 	if err != nil {
 		return fmt.Errorf("detection failed: %w", err)
 	}
 
+	// Rank every provider for --all
+	var candidates []detector.Candidate
+	if planAll {
+		candidates = d.Rank()
+	}
+
 	if plan == nil {
+		if planAll && !planOutputJSON {
+			printCandidates(candidates, "")
+		}
 		fmt.Println("No supported application detected")
 		return nil
 	}
 
-	// Apply custom packages (CLI > env > detected)
-	applyCustomPackages(plan, planPackages)
-
-	// Parse and apply build environment variables
-	if len(planBuildEnvs) > 0 {
-		envMap := planParseEnvVars(planBuildEnvs)
-		if len(envMap) > 0 {
-			plan.BuildEnv = envMap
-		}
+	// Apply overrides (CLI > env > coolpack.toml > detected)
+	if err := applyOverrides(plan, absPath, planFlags); err != nil {
+		return err
 	}
 
 	// Write to file if --out is specified
@@ -111,73 +147,96 @@ func runPlan(cmd *cobra.Command, args []string) error {
 	if planOutputJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
+		if planAll {
+			return enc.Encode(struct {
+				Candidates []detector.Candidate `json:"candidates"`
+				Plan       *detector.Plan       `json:"plan"`
+			}{candidates, plan})
+		}
 		return enc.Encode(plan)
 	}
 
-	// Pretty print the plan
+	// Pretty print the candidates and the plan
+	if planAll {
+		printCandidates(candidates, plan.Provider)
+	}
 	printPlan(plan)
-	return nil
-}
+	if planExplain {
+		printExplain(plan)
+	}
+
+	// Point out the apps of a monorepo built as a whole
+	if plan.Workspace.IsMonorepo && plan.Workspace.App == "" {
+		if apps, err := d.Apps(); err == nil && len(apps) > 0 {
+			fmt.Printf("\nNote: this is a monorepo with %d deployable apps. Use --list-apps to see them and --app to build one.\n", len(apps))
+		}
+	}
 
-// planParseEnvVars parses environment variable arguments
-// Supports KEY=value format or KEY (pulls from current environment)
-func planParseEnvVars(envArgs []string) map[string]string {
-	result := make(map[string]string)
-	for _, env := range envArgs {
-		if idx := strings.Index(env, "="); idx != -1 {
-			// KEY=value format
-			key := env[:idx]
-			value := env[idx+1:]
-			result[key] = value
-		} else {
-			// KEY only - pull from current environment
-			if value, exists := os.LookupEnv(env); exists {
-				result[env] = value
+	// Point out a Dockerfile the plan doesn't use
+	if plan.Provider != "dockerfile" {
+		for _, f := range dockerfile.DefaultFiles {
+			if _, err := os.Stat(filepath.Join(absPath, f)); err == nil {
+				fmt.Printf("\nNote: found %s. Set COOLPACK_USE_DOCKERFILE=true to build it instead.\n", f)
+				break
 			}
 		}
 	}
-	return result
+	return nil
 }
 
-// applyCustomPackages adds custom APT packages to the plan
-func applyCustomPackages(plan *detector.Plan, packages []string) {
-	if plan.Metadata == nil {
-		plan.Metadata = make(map[string]interface{})
+// listApps prints the deployable apps of a monorepo
+func listApps(d *detector.Detector) error {
+	apps, err := d.Apps()
+	if err != nil {
+		return fmt.Errorf("app discovery failed: %w", err)
 	}
 
-	// Collect packages from CLI and env
-	var customPackages []string
+	if planOutputJSON {
+		if apps == nil {
+			apps = []node.WorkspaceApp{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(apps)
+	}
 
-	// CLI packages
-	if len(packages) > 0 {
-		customPackages = append(customPackages, packages...)
+	if len(apps) == 0 {
+		fmt.Println("No monorepo apps detected")
+		return nil
 	}
 
-	// Environment variable (comma-separated)
-	if env := os.Getenv("COOLPACK_PACKAGES"); env != "" {
-		for _, pkg := range strings.Split(env, ",") {
-			pkg = strings.TrimSpace(pkg)
-			if pkg != "" {
-				customPackages = append(customPackages, pkg)
-			}
+	fmt.Println("=== Monorepo Apps ===")
+	fmt.Println()
+	for _, a := range apps {
+		details := a.Framework
+		if a.OutputType != "" {
+			details = fmt.Sprintf("%s (%s)", details, a.OutputType)
 		}
+		fmt.Printf("  %-24s %-24s %s\n", a.Path, a.Name, strings.TrimSpace(details))
 	}
+	fmt.Println()
+	fmt.Println("Build one with: coolpack build --app <path>")
+	return nil
+}
 
-	if len(customPackages) == 0 {
-		return
+// printCandidates prints every provider that matched, marking the selected one
+func printCandidates(candidates []detector.Candidate, selected string) {
+	fmt.Println("=== Detection Candidates ===")
+	fmt.Println()
+	if len(candidates) == 0 {
+		fmt.Println("  (none)")
 	}
-
-	// Deduplicate
-	seen := make(map[string]bool)
-	unique := make([]string, 0, len(customPackages))
-	for _, pkg := range customPackages {
-		if !seen[pkg] {
-			seen[pkg] = true
-			unique = append(unique, pkg)
+	for _, c := range candidates {
+		marker := " "
+		if c.Provider == selected {
+			marker = "*"
+		}
+		fmt.Printf("%s %-10s %3d  %s\n", marker, c.Provider, c.Confidence, strings.Join(c.Reasons, ", "))
+		if c.Error != "" {
+			fmt.Printf("  %-10s      error: %s\n", "", c.Error)
 		}
 	}
-
-	plan.Metadata["custom_packages"] = unique
+	fmt.Println()
 }
 
 func printPlan(plan *detector.Plan) {
@@ -209,6 +268,19 @@ func printPlan(plan *detector.Plan) {
 	if plan.StartCommand != "" {
 		fmt.Printf("Start Command:           %s\n", plan.StartCommand)
 	}
+	if len(plan.Processes) > 0 {
+		fmt.Println()
+		fmt.Println("Processes:")
+		// Sort keys for consistent output
+		names := make([]string, 0, len(plan.Processes))
+		for name := range plan.Processes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %s: %s\n", name, plan.Processes[name])
+		}
+	}
 	if len(plan.DetectedFiles) > 0 {
 		fmt.Println()
 		fmt.Println("Detected Files:")
@@ -229,17 +301,68 @@ func printPlan(plan *detector.Plan) {
 			fmt.Printf("  %s=%s\n", k, plan.BuildEnv[k])
 		}
 	}
-	if len(plan.Metadata) > 0 {
+	if fields := plan.SectionFields(); len(fields) > 0 {
 		fmt.Println()
-		fmt.Println("Metadata:")
-		// Sort keys for consistent output
-		keys := make([]string, 0, len(plan.Metadata))
-		for k := range plan.Metadata {
-			keys = append(keys, k)
+		fmt.Println("Settings:")
+		for _, f := range fields {
+			fmt.Printf("  %s: %v\n", f.Key(), f.Value)
 		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			fmt.Printf("  %s: %v\n", k, plan.Metadata[k])
+	}
+}
+
+// printExplain prints where every plan field came from
+// Fields without a recorded source were set by the provider's own rules
+func printExplain(plan *detector.Plan) {
+	fmt.Println()
+	fmt.Println("=== Plan Provenance ===")
+	fmt.Println()
+	fields := []struct {
+		field, label, value string
+	}{
+		{"provider", "Provider", plan.Provider},
+		{"language", "Language", plan.Language},
+		{"language_version", "Language Version", plan.LanguageVersion},
+		{"framework", "Framework", plan.Framework},
+		{"package_manager", "Package Manager", plan.PackageManager},
+		{"install_command", "Install Command", plan.InstallCommand},
+		{"build_command", "Build Command", plan.BuildCommand},
+		{"start_command", "Start Command", plan.StartCommand},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			printSource(f.label, f.value, explainSource(plan, f.field))
+		}
+	}
+	if len(plan.BuildEnv) > 0 {
+		printSource("Build Environment", fmt.Sprintf("%d variables", len(plan.BuildEnv)), explainSource(plan, "build_env"))
+	}
+
+	// Settings set from a file, a flag or an environment variable
+	var sourced []app.SectionField
+	for _, f := range plan.SectionFields() {
+		if plan.Source(f.Key()) != "" {
+			sourced = append(sourced, f)
 		}
 	}
+	if len(sourced) > 0 {
+		fmt.Println()
+		fmt.Println("Settings:")
+		for _, f := range sourced {
+			printSource("  "+f.Key(), fmt.Sprintf("%v", f.Value), plan.Source(f.Key()))
+		}
+	}
+}
+
+// printSource prints a field's value and where it came from
+func printSource(label, value, source string) {
+	fmt.Printf("%-24s %s\n", label+":", value)
+	fmt.Printf("%-24s <- %s\n", "", source)
+}
+
+// explainSource returns where a plan field came from
+func explainSource(plan *detector.Plan, field string) string {
+	if source := plan.Source(field); source != "" {
+		return source
+	}
+	return fmt.Sprintf("%s provider rules", plan.Provider)
 }
@@ -14,15 +14,47 @@ var rootCmd = &cobra.Command{
 generates Dockerfiles, and builds container images.
 
 Currently supports:
-  - Node.js (npm, yarn, pnpm, bun)
+  - Node.js (npm, yarn, pnpm, bun, monorepos with --app)
+  - Python (pip, poetry, uv, pipenv)
+  - Go (modules)
+  - Rust (cargo)
+  - Ruby (bundler)
+  - PHP (composer)
+  - Java (maven, gradle)
+  - .NET (dotnet publish)
+  - Elixir (mix)
+  - Deno (deno tasks)
+  - Static HTML (index.html)
+  - Hugo, Jekyll, MkDocs (static site generators)
+  - Existing Dockerfile (opt-in with COOLPACK_USE_DOCKERFILE)
 
 Environment Variables:
   COOLPACK_INSTALL_CMD     Override install command
   COOLPACK_BUILD_CMD       Override build command
   COOLPACK_START_CMD       Override start command
   COOLPACK_BASE_IMAGE      Override base Docker image (e.g., node:20-alpine)
+  COOLPACK_BASE_VARIANT    Base image variant: debian-slim (default), alpine, distroless
   COOLPACK_NODE_VERSION    Override Node.js version
-  COOLPACK_STATIC_SERVER   Static file server: caddy (default), nginx`,
+  COOLPACK_NODE_RUNNER     Node.js server runner: node (default), distroless
+  COOLPACK_PYTHON_VERSION  Override Python version
+  COOLPACK_GO_VERSION      Override Go version
+  COOLPACK_RUST_VERSION    Override Rust toolchain
+  COOLPACK_RUBY_VERSION    Override Ruby version
+  COOLPACK_PHP_VERSION     Override PHP version
+  COOLPACK_JAVA_VERSION    Override Java version
+  COOLPACK_DOTNET_VERSION  Override .NET version
+  COOLPACK_ELIXIR_VERSION  Override Elixir version
+  COOLPACK_DENO_VERSION    Override Deno version
+  COOLPACK_HUGO_VERSION    Override Hugo version
+  COOLPACK_MKDOCS_VERSION  Override MkDocs version (sites without dependency files)
+  COOLPACK_STATIC_SERVER   Static file server: caddy (default), nginx
+  COOLPACK_USE_DOCKERFILE  Build the project's own Dockerfile
+  COOLPACK_PROVIDER        Force a provider instead of the highest ranked one
+  COOLPACK_APP             Monorepo app to build (e.g., apps/web)
+
+Overrides can also be checked in as coolpack.toml in the project root.
+Native dependencies can be added in coolpack.native.json (see coolpack native list).
+Priority: CLI flags > Environment variables > coolpack.toml > Auto-detected`,
 }
 
 func Execute() {
@@ -38,4 +70,7 @@ func init() {
 	rootCmd.AddCommand(buildCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(nativeCmd)
+	planCmd.AddCommand(planValidateCmd)
 }
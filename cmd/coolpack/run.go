@@ -100,9 +100,12 @@ func runRun(cmd *cobra.Command, args []string) error {
 
 	// Determine port based on output type
 	port := "3000"
-	if ot, ok := plan.Metadata["output_type"].(string); ok && ot == "static" {
+	if plan.Runtime.OutputType == "static" {
 		port = "80"
 	}
+	if p := plan.Runtime.Port; p != "" {
+		port = p
+	}
 
 	// Build docker run arguments
 	dockerArgs := []string{"run", "--rm", "-it", "-p", fmt.Sprintf("%s:%s", port, port)}
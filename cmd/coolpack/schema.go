@@ -0,0 +1,69 @@
+package coolpack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of coolpack.json",
+	Long: `Print the JSON Schema of coolpack.json, for editor validation and completion.
+
+Example:
+  coolpack schema > coolpack.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, err := json.MarshalIndent(app.JSONSchema(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode schema: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	},
+}
+
+var planValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check a plan file before a build",
+	Long: `Check a plan file (default: coolpack.json) against the JSON Schema and the rules
+the schema can't express, such as a static output type needing an output directory.
+Every problem is listed. Plan files of older schema versions are upgraded first.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runPlanValidate,
+}
+
+func runPlanValidate(cmd *cobra.Command, args []string) error {
+	file := "coolpack.json"
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	plan, err := app.LoadPlan(data)
+	if err != nil {
+		var validationErr *app.ValidationError
+		if !errors.As(err, &validationErr) {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		fmt.Fprintf(os.Stderr, "%s is invalid:\n", file)
+		for _, problem := range validationErr.Problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		return fmt.Errorf("%d problem(s) found", len(validationErr.Problems))
+	}
+
+	fmt.Printf("%s is valid (provider: %s, schema_version %d)\n", file, plan.Provider, plan.SchemaVersion)
+	return nil
+}
@@ -55,3 +55,14 @@ func (ctx *Context) ListFiles(pattern string) ([]string, error) {
 
 	return result, nil
 }
+
+// HasMatch checks if any file matches one of the glob patterns in the application path
+// Used by providers detected by extension rather than a fixed filename (e.g., *.csproj)
+func (ctx *Context) HasMatch(patterns ...string) bool {
+	for _, pattern := range patterns {
+		if matches, err := ctx.ListFiles(pattern); err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
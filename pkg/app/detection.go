@@ -0,0 +1,57 @@
+package app
+
+// Confidence levels providers use to score a detection, so scores from
+// different providers are comparable when the detector ranks them
+const (
+	// ConfidenceNone means the provider doesn't apply
+	ConfidenceNone = 0
+
+	// ConfidenceWeak is a hint that is usually tooling or a secondary part of another project
+	// (a Gemfile next to package.json, an index.html)
+	ConfidenceWeak = 20
+
+	// ConfidenceManifest is a language manifest on its own (package.json, go.mod, pom.xml)
+	ConfidenceManifest = 60
+
+	// ConfidenceApp is a manifest plus an application framework or entry point
+	ConfidenceApp = 80
+
+	// ConfidenceSpecific is configuration that only exists for one kind of project
+	// (hugo.toml, deno.json, a Jekyll site)
+	ConfidenceSpecific = 90
+
+	// ConfidenceExplicit is a choice the user made (COOLPACK_USE_DOCKERFILE)
+	ConfidenceExplicit = 100
+)
+
+// Detection is a provider's verdict on an application
+type Detection struct {
+	// Confidence scores the match from ConfidenceNone to ConfidenceExplicit
+	Confidence int `json:"confidence"`
+
+	// Reasons explains the score (e.g., "found Gemfile", "config.ru is a Rack entry point")
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Matched returns true if the provider can build the application
+func (d Detection) Matched() bool {
+	return d.Confidence > ConfidenceNone
+}
+
+// NoMatch returns a Detection for a provider that doesn't apply
+func NoMatch() Detection {
+	return Detection{Confidence: ConfidenceNone}
+}
+
+// Detected returns a Detection with the given confidence and reasons
+func Detected(confidence int, reasons ...string) Detection {
+	return Detection{Confidence: confidence, Reasons: reasons}
+}
+
+// Raise increases the confidence to at least confidence and records the reason
+func (d *Detection) Raise(confidence int, reason string) {
+	if confidence > d.Confidence {
+		d.Confidence = confidence
+	}
+	d.Reasons = append(d.Reasons, reason)
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// fieldEnums are the values a plan field accepts, keyed by "<section>.<field>"
+var fieldEnums = map[string][]string{
+	"runtime.output_type":  {"server", "static"},
+	"runtime.base_variant": {VariantDebianSlim, VariantAlpine, VariantDistroless},
+	"static.server":        {"caddy", "nginx"},
+	"workspace.tool":       {"turbo", "nx", "moon", "workspaces"},
+}
+
+// JSONSchema returns the JSON Schema of coolpack.json, generated from the Plan type
+func JSONSchema() map[string]interface{} {
+	schema := typeSchema(reflect.TypeOf(Plan{}), "")
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "coolpack.json"
+	schema["description"] = fmt.Sprintf("Coolpack build plan (schema_version %d)", SchemaVersion)
+	schema["required"] = []string{"schema_version", "provider"}
+	properties := schema["properties"].(map[string]interface{})
+	properties["schema_version"] = map[string]interface{}{
+		"type":  "integer",
+		"const": SchemaVersion,
+	}
+	// Editors find the schema through a "$schema" reference in the plan file
+	properties["$schema"] = map[string]interface{}{"type": "string"}
+	return schema
+}
+
+// typeSchema returns the schema of a Go type, path is the field's "<section>.<field>" key
+func typeSchema(t reflect.Type, path string) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.String:
+		schema := map[string]interface{}{"type": "string"}
+		if values, ok := fieldEnums[path]; ok {
+			schema["enum"] = values
+		}
+		return schema
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), path)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), path)}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			name := jsonName(t.Field(i))
+			key := name
+			if path != "" {
+				key = path + "." + name
+			}
+			properties[name] = typeSchema(t.Field(i).Type, key)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "additionalProperties": false}
+	}
+	return map[string]interface{}{}
+}
+
+// validateSchema checks a decoded JSON value against a schema from typeSchema
+// and returns every problem found, path is the value's location in the plan
+func validateSchema(schema map[string]interface{}, value interface{}, path string) []string {
+	var problems []string
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []string{typeProblem(path, "an object", value)}
+		}
+		required, _ := schema["required"].([]string)
+		for _, name := range required {
+			if _, ok := object[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s is required", fieldPath(path, name)))
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := properties[key]; ok {
+				problems = append(problems, validateSchema(property.(map[string]interface{}), object[key], fieldPath(path, key))...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case map[string]interface{}:
+				problems = append(problems, validateSchema(additional, object[key], fieldPath(path, key))...)
+			case bool:
+				problem := fmt.Sprintf("%s: unknown field", fieldPath(path, key))
+				if suggestion := suggestField(key, properties); suggestion != "" {
+					problem += fmt.Sprintf(" (did you mean %s?)", fieldPath(path, suggestion))
+				}
+				problems = append(problems, problem)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return []string{typeProblem(path, "an array", value)}
+		}
+		items := schema["items"].(map[string]interface{})
+		for i, item := range array {
+			problems = append(problems, validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []string{typeProblem(path, "a string", value)}
+		}
+		if values, ok := schema["enum"].([]string); ok && !contains(values, s) {
+			problems = append(problems, fmt.Sprintf("%s must be one of %s, got %q", path, strings.Join(values, ", "), s))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []string{typeProblem(path, "a boolean", value)}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return []string{typeProblem(path, "an integer", value)}
+		}
+		n, err := number.Int64()
+		if err != nil {
+			return []string{typeProblem(path, "an integer", value)}
+		}
+		if want, ok := schema["const"].(int); ok && n != int64(want) {
+			problems = append(problems, fmt.Sprintf("%s must be %d, got %d", path, want, n))
+		}
+	}
+	return problems
+}
+
+// typeProblem describes a value of the wrong type
+func typeProblem(path, want string, value interface{}) string {
+	var got string
+	switch value.(type) {
+	case nil:
+		got = "null"
+	case map[string]interface{}:
+		got = "an object"
+	case []interface{}:
+		got = "an array"
+	case string:
+		got = "a string"
+	case bool:
+		got = "a boolean"
+	case json.Number:
+		got = "a number"
+	}
+	return fmt.Sprintf("%s must be %s, got %s", path, want, got)
+}
+
+// fieldPath joins a field name to the location of its object
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// suggestField returns the known field closest to an unknown one, or "" if none is close.
+// Section fields are matched in their flattened form too, so "static_server" suggests "static.server"
+func suggestField(key string, properties map[string]interface{}) string {
+	best, bestDistance := "", 3
+	for name, property := range properties {
+		candidates := map[string]string{name: name}
+		if nested, ok := property.(map[string]interface{})["properties"].(map[string]interface{}); ok {
+			for field := range nested {
+				candidates[name+"_"+field] = name + "." + field
+			}
+		}
+		for flat, suggestion := range candidates {
+			distance := editDistance(key, flat)
+			if distance < bestDistance || (distance == bestDistance && suggestion < best) {
+				best, bestDistance = suggestion, distance
+			}
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// contains reports whether a list holds a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,9 @@ package app
 
 // Plan represents the detected build plan for an application
 type Plan struct {
+	// SchemaVersion is the version of the plan format (see SchemaVersion)
+	SchemaVersion int `json:"schema_version"`
+
 	// Provider is the name of the provider that detected this application (e.g., "node")
 	Provider string `json:"provider"`
 
@@ -32,15 +35,70 @@ type Plan struct {
 	// StartCommand is the command to start the application
 	StartCommand string `json:"start_command,omitempty"`
 
+	// Processes are additional process types from the Procfile (e.g., worker, release),
+	// keyed by type. The web process is the StartCommand
+	Processes map[string]string `json:"processes,omitempty"`
+
 	// DetectedFiles lists the files that were used for detection
 	DetectedFiles []string `json:"detected_files,omitempty"`
 
-	// Metadata contains additional provider-specific information
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Project describes the application from its manifest
+	Project ProjectSection `json:"project,omitzero"`
+
+	// Runtime describes how the image runs
+	Runtime RuntimeSection `json:"runtime,omitzero"`
+
+	// Static configures how static output is served
+	Static StaticSection `json:"static,omitzero"`
+
+	// Packages lists the system packages installed in the image
+	Packages PackagesSection `json:"packages,omitzero"`
+
+	// Cache configures the BuildKit cache mounts
+	Cache CacheSection `json:"cache,omitzero"`
+
+	// Workspace describes a monorepo and the app selected from it
+	Workspace WorkspaceSection `json:"workspace,omitzero"`
+
+	// Tools lists toolchains installed next to the language's own (e.g., Node.js for assets)
+	Tools ToolsSection `json:"tools,omitzero"`
+
+	// Language-specific build details
+	Go         GoSection         `json:"go,omitzero"`
+	Rust       RustSection       `json:"rust,omitzero"`
+	Python     PythonSection     `json:"python,omitzero"`
+	PHP        PHPSection        `json:"php,omitzero"`
+	Java       JavaSection       `json:"java,omitzero"`
+	DotNet     DotNetSection     `json:"dotnet,omitzero"`
+	Elixir     ElixirSection     `json:"elixir,omitzero"`
+	Deno       DenoSection       `json:"deno,omitzero"`
+	Dockerfile DockerfileSection `json:"dockerfile,omitzero"`
 
 	// BuildEnv contains environment variables available during build (ARG in Dockerfile)
 	BuildEnv map[string]string `json:"build_env,omitempty"`
 
 	// Env contains environment variables available at runtime (ENV in Dockerfile)
 	Env map[string]string `json:"env,omitempty"`
+
+	// Provenance records where each field's value came from (a file, an environment
+	// variable, a CLI flag or a detection rule), keyed by JSON field name.
+	// Section fields are recorded as "<section>.<field>" (e.g., "runtime.output_type")
+	Provenance map[string]string `json:"provenance,omitempty"`
+}
+
+// SetSource records where the value of a plan field came from
+// An empty source records nothing
+func (p *Plan) SetSource(field, source string) {
+	if source == "" {
+		return
+	}
+	if p.Provenance == nil {
+		p.Provenance = make(map[string]string)
+	}
+	p.Provenance[field] = source
+}
+
+// Source returns where the value of a plan field came from, or "" if it wasn't recorded
+func (p *Plan) Source(field string) string {
+	return p.Provenance[field]
 }
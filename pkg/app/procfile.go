@@ -0,0 +1,44 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Process is a process type declared in a Procfile
+type Process struct {
+	// Name is the process type (e.g., "web", "worker", "release")
+	Name string
+
+	// Command is the command the process runs
+	Command string
+}
+
+// procfileLineRegex matches a "type: command" Procfile entry
+var procfileLineRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.+)$`)
+
+// ParseProcfile parses Procfile content (Heroku format) into its process types,
+// in declaration order. Blank lines and comments are skipped
+func ParseProcfile(content string) []Process {
+	var processes []Process
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if matches := procfileLineRegex.FindStringSubmatch(line); len(matches) > 2 {
+			processes = append(processes, Process{Name: matches[1], Command: strings.TrimSpace(matches[2])})
+		}
+	}
+	return processes
+}
+
+// ReadProcfile reads the process types from the Procfile in the application root
+// Returns nil if there is no Procfile
+func (ctx *Context) ReadProcfile() []Process {
+	data, err := ctx.ReadFile("Procfile")
+	if err != nil {
+		return nil
+	}
+	return ParseProcfile(string(data))
+}
@@ -0,0 +1,266 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaVersion is the version of the plan format written by this release
+// Version 1 kept provider details in a free-form "metadata" map
+const SchemaVersion = 2
+
+// migrations upgrade a plan file one schema version at a time:
+// migrations[v] upgrades version v to v+1
+var migrations = map[int]func(plan map[string]json.RawMessage) error{
+	1: migrateMetadataToSections,
+}
+
+// LoadPlan decodes a plan file, upgrading older schema versions first
+// The plan is checked against the JSON Schema and Validate, and every problem is reported
+func LoadPlan(data []byte) (*Plan, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	// Plan files written before schema_version existed are version 1
+	version := 1
+	if v, ok := raw["schema_version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, fmt.Errorf("schema_version must be a number")
+		}
+	}
+	if version < 1 || version > SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema_version %d (this version of coolpack reads 1 to %d)", version, SchemaVersion)
+	}
+
+	for ; version < SchemaVersion; version++ {
+		if err := migrations[version](raw); err != nil {
+			return nil, fmt.Errorf("failed to upgrade plan from schema_version %d: %w", version, err)
+		}
+	}
+	raw["schema_version"] = json.RawMessage(fmt.Sprint(SchemaVersion))
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	dec := json.NewDecoder(bytes.NewReader(upgraded))
+	dec.UseNumber()
+	if err := dec.Decode(&document); err != nil {
+		return nil, err
+	}
+	if problems := validateSchema(JSONSchema(), document, ""); len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(upgraded, &plan); err != nil {
+		return nil, fmt.Errorf("invalid plan: %w", err)
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// ValidationError lists every problem found in a plan
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid plan: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the rules the schema can't express, such as fields that need each other
+func (p *Plan) Validate() error {
+	var problems []string
+	if p.Provider == "" {
+		problems = append(problems, "provider is required")
+	}
+	if p.Runtime.OutputType == "static" && p.Static.OutputDir == "" && p.Static.SiteDir == "" {
+		problems = append(problems, "runtime.output_type static needs static.output_dir")
+	}
+	if p.Provider == "dockerfile" && p.Dockerfile.File == "" {
+		problems = append(problems, "provider dockerfile needs dockerfile.file")
+	}
+	if (p.Runtime.BaseVariant == VariantAlpine || p.Runtime.BaseVariant == VariantDistroless) && p.Provider != "node" {
+		problems = append(problems, fmt.Sprintf("runtime.base_variant %s needs provider node", p.Runtime.BaseVariant))
+	}
+	if p.Workspace.App != "" && p.Workspace.Tool == "" {
+		problems = append(problems, "workspace.app needs workspace.tool")
+	}
+	names := make([]string, 0, len(p.Processes))
+	for name := range p.Processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(p.Processes[name]) == "" {
+			problems = append(problems, fmt.Sprintf("processes.%s has no command", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// metadataSections maps schema version 1 metadata keys to their section fields
+var metadataSections = map[string][2]string{
+	"name":                 {"project", "name"},
+	"version":              {"project", "version"},
+	"module_type":          {"project", "module_type"},
+	"output_type":          {"runtime", "output_type"},
+	"base_image":           {"runtime", "base_image"},
+	"runtime":              {"runtime", "name"},
+	"runtime_note":         {"runtime", "note"},
+	"runner":               {"runtime", "runner"},
+	"process":              {"runtime", "process"},
+	"port":                 {"runtime", "port"},
+	"static_server":        {"static", "server"},
+	"is_spa":               {"static", "spa"},
+	"output_dir_override":  {"static", "output_dir"},
+	"site_dir":             {"static", "site_dir"},
+	"apt_packages":         {"packages", "apt"},
+	"runtime_apt_packages": {"packages", "runtime_apt"},
+	"native_packages":      {"packages", "native"},
+	"custom_packages":      {"packages", "custom"},
+	"cache_directories":    {"cache", "directories"},
+	"has_cypress":          {"cache", "cypress"},
+	"has_moon":             {"cache", "moon"},
+	"is_monorepo":          {"workspace", "is_monorepo"},
+	"workspaces":           {"workspace", "globs"},
+	"workspace_tool":       {"workspace", "tool"},
+	"app":                  {"workspace", "app"},
+	"app_name":             {"workspace", "app_name"},
+	"app_project":          {"workspace", "app_project"},
+	"workdir":              {"workspace", "workdir"},
+	"workspace_manifests":  {"workspace", "manifests"},
+	"turbo_version":        {"workspace", "turbo_version"},
+	"node_version":         {"tools", "node_version"},
+	"node_package_manager": {"tools", "node_package_manager"},
+	"go_version":           {"tools", "go_version"},
+	"main_package":         {"go", "main_package"},
+	"main_packages":        {"go", "main_packages"},
+	"module":               {"go", "module"},
+	"cgo":                  {"go", "cgo"},
+	"binary":               {"rust", "binary"},
+	"binaries":             {"rust", "binaries"},
+	"is_workspace":         {"rust", "is_workspace"},
+	"server_packages":      {"python", "server_packages"},
+	"dependency_files":     {"python", "dependency_files"},
+	"document_root":        {"php", "document_root"},
+	"php_extensions":       {"php", "extensions"},
+	"wrapper":              {"java", "wrapper"},
+	"artifact":             {"java", "artifact"},
+	"project":              {"dotnet", "project"},
+	"projects":             {"dotnet", "projects"},
+	"erlang_version":       {"elixir", "erlang_version"},
+	"release":              {"elixir", "release"},
+	"is_umbrella":          {"elixir", "is_umbrella"},
+	"entrypoint":           {"deno", "entrypoint"},
+	"dockerfile":           {"dockerfile", "file"},
+	"images":               {"dockerfile", "images"},
+	"exposed_ports":        {"dockerfile", "exposed_ports"},
+}
+
+// v1OutputDirs are the static output directories version 1 used for each framework
+var v1OutputDirs = map[string]string{
+	"nextjs":         "out",
+	"nuxt":           ".output/public",
+	"gatsby":         "public",
+	"hugo":           "public",
+	"sveltekit":      "build",
+	"solid-start":    ".output/public",
+	"tanstack-start": ".output/public",
+	"eleventy":       "_site",
+	"lume":           "_site",
+	"jekyll":         "_site",
+	"mkdocs":         "site",
+}
+
+// migrateMetadataToSections moves the version 1 metadata map into typed sections,
+// along with the provenance recorded for its keys
+func migrateMetadataToSections(plan map[string]json.RawMessage) error {
+	rawMetadata, ok := plan["metadata"]
+	delete(plan, "metadata")
+	if !ok {
+		return nil
+	}
+
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
+		return fmt.Errorf("metadata must be an object")
+	}
+
+	sections := make(map[string]map[string]json.RawMessage)
+	var unknown []string
+	for key, value := range metadata {
+		target, ok := metadataSections[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		if sections[target[0]] == nil {
+			sections[target[0]] = make(map[string]json.RawMessage)
+		}
+		sections[target[0]][target[1]] = value
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown metadata keys: %s", strings.Join(unknown, ", "))
+	}
+
+	// Version 1 left the static output directory to the generator's framework defaults
+	if string(sections["runtime"]["output_type"]) == `"static"` && sections["static"]["output_dir"] == nil && sections["static"]["site_dir"] == nil {
+		var framework string
+		json.Unmarshal(plan["framework"], &framework)
+		outputDir, ok := v1OutputDirs[framework]
+		if !ok {
+			outputDir = "dist"
+		}
+		if sections["static"] == nil {
+			sections["static"] = make(map[string]json.RawMessage)
+		}
+		sections["static"]["output_dir"], _ = json.Marshal(outputDir)
+	}
+
+	for name, fields := range sections {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		plan[name] = data
+	}
+
+	// Provenance keys of metadata moved with their values
+	if rawProvenance, ok := plan["provenance"]; ok {
+		var provenance map[string]string
+		if err := json.Unmarshal(rawProvenance, &provenance); err != nil {
+			return fmt.Errorf("provenance must be an object of strings")
+		}
+		for field, source := range provenance {
+			key, ok := strings.CutPrefix(field, "metadata.")
+			if !ok {
+				continue
+			}
+			delete(provenance, field)
+			if target, ok := metadataSections[key]; ok {
+				provenance[target[0]+"."+target[1]] = source
+			}
+		}
+		data, err := json.Marshal(provenance)
+		if err != nil {
+			return err
+		}
+		plan["provenance"] = data
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Plan sections group the details providers hand to the generator.
+// Every section is left out of coolpack.json when empty
+
+// ProjectSection describes the application from its manifest
+type ProjectSection struct {
+	// Name is the application name (package.json, pyproject.toml, mix.exs, ...)
+	Name string `json:"name,omitempty"`
+
+	// Version is the application version
+	Version string `json:"version,omitempty"`
+
+	// ModuleType is the package.json module type (module or commonjs)
+	ModuleType string `json:"module_type,omitempty"`
+}
+
+// RuntimeSection describes how the image runs
+type RuntimeSection struct {
+	// OutputType is what the build produces: "server" (needs a runtime) or "static" (served as files)
+	OutputType string `json:"output_type,omitempty"`
+
+	// BaseImage overrides the base Docker image
+	BaseImage string `json:"base_image,omitempty"`
+
+	// BaseVariant is the base image variant: debian-slim (default) or alpine
+	BaseVariant string `json:"base_variant,omitempty"`
+
+	// Name is the runtime when it differs from the language's default (bun, aspnet, runtime)
+	Name string `json:"name,omitempty"`
+
+	// Note explains the runtime choice
+	Note string `json:"note,omitempty"`
+
+	// Runner is the production stage variant (Go: distroless or scratch, PHP: frankenphp or fpm,
+	// Node.js: node or distroless)
+	Runner string `json:"runner,omitempty"`
+
+	// Process is the Procfile process type the image starts instead of web
+	Process string `json:"process,omitempty"`
+
+	// Port is the port the application listens on, when the project declares one
+	Port string `json:"port,omitempty"`
+}
+
+// StaticSection configures how static output is served
+type StaticSection struct {
+	// Server is the static file server: caddy (default) or nginx
+	Server string `json:"server,omitempty"`
+
+	// SPA serves index.html for unknown routes
+	SPA bool `json:"spa,omitempty"`
+
+	// OutputDir is the directory the build writes static files to (the framework's default or --output-dir)
+	OutputDir string `json:"output_dir,omitempty"`
+
+	// SiteDir is the directory of a plain static site (e.g., public)
+	SiteDir string `json:"site_dir,omitempty"`
+}
+
+// PackagesSection lists the system packages installed in the image
+type PackagesSection struct {
+	// Apt are the APT packages needed to build the application
+	Apt []string `json:"apt,omitempty"`
+
+	// RuntimeApt are the APT packages needed to run the application
+	RuntimeApt []string `json:"runtime_apt,omitempty"`
+
+	// Native are the dependencies that required APT packages (e.g., sharp, bcrypt)
+	Native []string `json:"native,omitempty"`
+
+	// GlibcOnly are the native dependencies that don't run on musl (Alpine)
+	GlibcOnly []string `json:"glibc_only,omitempty"`
+
+	// Custom are the packages added with --packages or COOLPACK_PACKAGES
+	Custom []string `json:"custom,omitempty"`
+}
+
+// CacheSection configures the BuildKit cache mounts
+type CacheSection struct {
+	// Directories are extra cache directories (package.json cacheDirectories)
+	Directories []string `json:"directories,omitempty"`
+
+	// Cypress caches the Cypress binary download
+	Cypress bool `json:"cypress,omitempty"`
+
+	// Moon caches the moon repo cache
+	Moon bool `json:"moon,omitempty"`
+}
+
+// WorkspaceSection describes a monorepo and the app selected from it
+type WorkspaceSection struct {
+	// IsMonorepo is set when the project declares workspaces
+	IsMonorepo bool `json:"is_monorepo,omitempty"`
+
+	// Globs are the workspace package globs
+	Globs []string `json:"globs,omitempty"`
+
+	// Tool is the monorepo tool (turbo, nx, moon or workspaces)
+	Tool string `json:"tool,omitempty"`
+
+	// App is the path of the selected app (e.g., apps/web)
+	App string `json:"app,omitempty"`
+
+	// AppName is the package name of the selected app
+	AppName string `json:"app_name,omitempty"`
+
+	// AppProject is the Nx or Moon project name of the selected app
+	AppProject string `json:"app_project,omitempty"`
+
+	// Workdir is the directory the app builds and starts from, relative to the root
+	Workdir string `json:"workdir,omitempty"`
+
+	// Manifests are the workspace package.json files, copied before the install
+	Manifests []string `json:"manifests,omitempty"`
+
+	// TurboVersion is the Turborepo version used to prune the workspace
+	TurboVersion string `json:"turbo_version,omitempty"`
+}
+
+// ToolsSection lists toolchains installed next to the language's own
+type ToolsSection struct {
+	// NodeVersion is the Node.js version used to build assets
+	NodeVersion string `json:"node_version,omitempty"`
+
+	// NodePackageManager is the package manager used to build assets
+	NodePackageManager string `json:"node_package_manager,omitempty"`
+
+	// GoVersion is the Go version needed by Hugo Modules
+	GoVersion string `json:"go_version,omitempty"`
+}
+
+// GoSection holds Go build details
+type GoSection struct {
+	// MainPackage is the main package that is built
+	MainPackage string `json:"main_package,omitempty"`
+
+	// MainPackages are all main packages, when there is more than one
+	MainPackages []string `json:"main_packages,omitempty"`
+
+	// Module is the module path from go.mod
+	Module string `json:"module,omitempty"`
+
+	// CGO is the reason cgo is enabled, empty for pure Go builds
+	CGO string `json:"cgo,omitempty"`
+}
+
+// RustSection holds Rust build details
+type RustSection struct {
+	// Binary is the binary target that is built
+	Binary string `json:"binary,omitempty"`
+
+	// Binaries are all binary targets, when there is more than one
+	Binaries []string `json:"binaries,omitempty"`
+
+	// IsWorkspace is set for Cargo workspaces
+	IsWorkspace bool `json:"is_workspace,omitempty"`
+}
+
+// PythonSection holds Python build details
+type PythonSection struct {
+	// ServerPackages are application servers the start command needs but the project doesn't declare
+	ServerPackages []string `json:"server_packages,omitempty"`
+
+	// DependencyFiles are copied before the install for better caching
+	DependencyFiles []string `json:"dependency_files,omitempty"`
+
+	// ProjectInstallCommand installs the project itself after the source is copied
+	// (entry points, src layout packages)
+	ProjectInstallCommand string `json:"project_install_command,omitempty"`
+}
+
+// PHPSection holds PHP build details
+type PHPSection struct {
+	// DocumentRoot is the web server's document root (e.g., public)
+	DocumentRoot string `json:"document_root,omitempty"`
+
+	// Extensions are the PHP extensions to install
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// JavaSection holds Java build details
+type JavaSection struct {
+	// Wrapper is the build tool wrapper script (mvnw or gradlew)
+	Wrapper string `json:"wrapper,omitempty"`
+
+	// Artifact is the build output copied into the runner (a jar glob or the quarkus-app directory)
+	Artifact string `json:"artifact,omitempty"`
+}
+
+// DotNetSection holds .NET build details
+type DotNetSection struct {
+	// Project is the project file that is published
+	Project string `json:"project,omitempty"`
+
+	// Projects are all publishable projects, when there is more than one
+	Projects []string `json:"projects,omitempty"`
+}
+
+// ElixirSection holds Elixir build details
+type ElixirSection struct {
+	// ErlangVersion is the Erlang/OTP version
+	ErlangVersion string `json:"erlang_version,omitempty"`
+
+	// Release is the name of the mix release
+	Release string `json:"release,omitempty"`
+
+	// IsUmbrella is set for umbrella projects
+	IsUmbrella bool `json:"is_umbrella,omitempty"`
+}
+
+// DenoSection holds Deno build details
+type DenoSection struct {
+	// Entrypoint is the module the server starts from
+	Entrypoint string `json:"entrypoint,omitempty"`
+}
+
+// DockerfileSection describes a project built from its own Dockerfile
+type DockerfileSection struct {
+	// File is the Dockerfile to build
+	File string `json:"file,omitempty"`
+
+	// Images are the images the Dockerfile builds from
+	Images []string `json:"images,omitempty"`
+
+	// ExposedPorts are the ports the Dockerfile exposes
+	ExposedPorts []string `json:"exposed_ports,omitempty"`
+}
+
+// SectionField is a field of a plan section that is set
+type SectionField struct {
+	// Section is the JSON name of the section (e.g., "runtime")
+	Section string
+
+	// Name is the JSON name of the field (e.g., "output_type")
+	Name string
+
+	// Value is the field's value
+	Value interface{}
+}
+
+// Key returns the field's provenance key (e.g., "runtime.output_type")
+func (f SectionField) Key() string {
+	return f.Section + "." + f.Name
+}
+
+// SectionFields returns the fields that are set in every section, in declaration order
+func (p *Plan) SectionFields() []SectionField {
+	var fields []SectionField
+	plan := reflect.ValueOf(p).Elem()
+	for i := 0; i < plan.NumField(); i++ {
+		section := plan.Field(i)
+		if section.Kind() != reflect.Struct {
+			continue
+		}
+		sectionName := jsonName(plan.Type().Field(i))
+		for j := 0; j < section.NumField(); j++ {
+			if value := section.Field(j); !value.IsZero() {
+				fields = append(fields, SectionField{
+					Section: sectionName,
+					Name:    jsonName(section.Type().Field(j)),
+					Value:   value.Interface(),
+				})
+			}
+		}
+	}
+	return fields
+}
+
+// jsonName returns the JSON name of a struct field
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
@@ -0,0 +1,120 @@
+package app
+
+import "fmt"
+
+// Base image variants (runtime.base_variant)
+const (
+	// VariantDebianSlim builds on the -slim Debian images with apt-get (default)
+	VariantDebianSlim = "debian-slim"
+
+	// VariantAlpine builds on the -alpine images with apk
+	VariantAlpine = "alpine"
+
+	// VariantDistroless builds on debian-slim and runs server apps on the distroless
+	// runner of the same name (runtime.runner)
+	VariantDistroless = "distroless"
+)
+
+// alpinePackages maps Debian packages to their Alpine equivalents
+var alpinePackages = map[string]string{
+	"build-essential":     "build-base",
+	"python3":             "python3",
+	"openssl":             "openssl",
+	"ca-certificates":     "ca-certificates",
+	"curl":                "curl",
+	"git":                 "git",
+	"libvips-dev":         "vips-dev",
+	"libvips42":           "vips",
+	"chromium":            "chromium",
+	"libnss3":             "nss",
+	"libatk1.0-0":         "at-spi2-core",
+	"libatk-bridge2.0-0":  "at-spi2-core",
+	"libcups2":            "cups-libs",
+	"libdrm2":             "libdrm",
+	"libxkbcommon0":       "libxkbcommon",
+	"libxcomposite1":      "libxcomposite",
+	"libxdamage1":         "libxdamage",
+	"libxfixes3":          "libxfixes",
+	"libxrandr2":          "libxrandr",
+	"libgbm1":             "mesa-gbm",
+	"libasound2":          "alsa-lib",
+	"libpango-1.0-0":      "pango",
+	"libpangocairo-1.0-0": "pango",
+	"libcairo2":           "cairo",
+	"libcairo2-dev":       "cairo-dev",
+	"libjpeg-dev":         "libjpeg-turbo-dev",
+	"libjpeg62-turbo":     "libjpeg-turbo",
+	"libpango1.0-dev":     "pango-dev",
+	"libgif-dev":          "giflib-dev",
+	"libgif7":             "giflib",
+	"librsvg2-dev":        "librsvg-dev",
+	"librsvg2-2":          "librsvg",
+}
+
+// AlpinePackages returns the Alpine names of Debian packages, deduplicated
+// Packages without a known equivalent keep their name, so Alpine names pass through
+func AlpinePackages(packages []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, pkg := range packages {
+		if alpine, ok := alpinePackages[pkg]; ok {
+			pkg = alpine
+		}
+		if !seen[pkg] {
+			seen[pkg] = true
+			result = append(result, pkg)
+		}
+	}
+	return result
+}
+
+// dockerfileIgnored are the settings a project's own Dockerfile, built as-is, doesn't use
+var dockerfileIgnored = []string{
+	"install_command",
+	"build_command",
+	"start_command",
+	"packages.custom",
+	"runtime.base_image",
+	"static.server",
+	"static.output_dir",
+	"static.spa",
+}
+
+// Warnings returns the problems of the plan that don't stop a build, such as native
+// dependencies that need glibc on the musl-based Alpine images, libraries the
+// distroless runner can't install, or overrides a project's own Dockerfile ignores
+func (p *Plan) Warnings() []string {
+	var warnings []string
+	if p.Runtime.BaseVariant == VariantAlpine {
+		for _, pkg := range p.Packages.GlibcOnly {
+			warnings = append(warnings, fmt.Sprintf("%s needs glibc and won't run on Alpine (musl), use base_variant %s", pkg, VariantDebianSlim))
+		}
+		seen := make(map[string]bool)
+		for _, pkg := range append(append([]string{}, p.Packages.Apt...), p.Packages.RuntimeApt...) {
+			if _, ok := alpinePackages[pkg]; !ok && !seen[pkg] {
+				seen[pkg] = true
+				warnings = append(warnings, fmt.Sprintf("no Alpine package known for %s, installing it under the same name", pkg))
+			}
+		}
+	}
+
+	// The distroless Node.js runner has no shell or package manager
+	if p.Provider == "node" && p.Runtime.Runner == "distroless" {
+		for _, pkg := range p.Packages.RuntimeApt {
+			warnings = append(warnings, fmt.Sprintf("the distroless runner can't install %s, native dependencies that load it will fail", pkg))
+		}
+		if len(p.Processes) > 0 {
+			warnings = append(warnings, "the distroless runner has no shell for the coolpack-process launcher, the image only starts its start command")
+		}
+	}
+
+	// The provider sets none of these, so they come from flags, env vars or coolpack.toml
+	if p.Provider == "dockerfile" && p.Dockerfile.File != "" {
+		for _, field := range dockerfileIgnored {
+			if source := p.Source(field); source != "" {
+				warnings = append(warnings, fmt.Sprintf("%s from %s is ignored, %s is built as-is", field, source, p.Dockerfile.File))
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"strings"
+)
+
+// ParseToolVersions parses .tool-versions file content (asdf format)
+// and returns the version pinned for the given tool
+// Format: tool-name version
+func ParseToolVersions(content string, tool string) string {
+	lines := strings.Split(content, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		parts := strings.Fields(line)
+		if len(parts) >= 2 && parts[0] == tool {
+			return strings.TrimPrefix(parts[1], "v")
+		}
+	}
+	return ""
+}
+
+// ReadToolVersion reads the version of a tool from the .tool-versions file
+// Returns an empty string if the file or the tool entry is missing
+func (ctx *Context) ReadToolVersion(tool string) string {
+	if !ctx.HasFile(".tool-versions") {
+		return ""
+	}
+	data, err := ctx.ReadFile(".tool-versions")
+	if err != nil {
+		return ""
+	}
+	return ParseToolVersions(string(data), tool)
+}
@@ -0,0 +1,101 @@
+// Package config reads coolpack.toml and layers settings over a build plan
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// FileName is the config file read from the application root
+const FileName = "coolpack.toml"
+
+// Config holds the settings of coolpack.toml
+// Detection still runs, and settings that aren't set keep the detected value
+type Config struct {
+	// InstallCommand overrides the install command
+	InstallCommand string `toml:"install_command"`
+
+	// BuildCommand overrides the build command
+	BuildCommand string `toml:"build_command"`
+
+	// StartCommand overrides the start command
+	StartCommand string `toml:"start_command"`
+
+	// Process is the Procfile process type to start instead of web
+	Process string `toml:"process"`
+
+	// Packages are additional APT packages, added to the detected ones
+	Packages []string `toml:"packages"`
+
+	// NodeVersion overrides the Node.js version (of the app, or of the asset build)
+	NodeVersion string `toml:"node_version"`
+
+	// BaseImage overrides the base Docker image
+	BaseImage string `toml:"base_image"`
+
+	// BaseVariant is the base image variant: debian-slim, alpine or distroless
+	BaseVariant string `toml:"base_variant"`
+
+	// StaticServer is the static file server: caddy or nginx
+	StaticServer string `toml:"static_server"`
+
+	// OutputDir overrides the static output directory
+	OutputDir string `toml:"output_dir"`
+
+	// SPA enables or disables SPA mode, nil keeps the detected value
+	SPA *bool `toml:"spa"`
+
+	// BuildEnv are build-time environment variables
+	BuildEnv map[string]string `toml:"build_env"`
+}
+
+// Load reads coolpack.toml from the application root
+// Returns nil if the file doesn't exist. Unknown keys are errors
+func Load(dir string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	var cfg Config
+	meta, err := toml.Decode(string(data), &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		var keys []string
+		unknown := make(map[string]bool)
+		for _, key := range undecoded {
+			// Keys of an unknown table are reported with the table
+			if len(key) > 1 && unknown[key[:len(key)-1].String()] {
+				unknown[key.String()] = true
+				continue
+			}
+			unknown[key.String()] = true
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("unknown keys in %s: %s", FileName, strings.Join(keys, ", "))
+	}
+	switch cfg.StaticServer {
+	case "", "caddy", "nginx":
+	default:
+		return nil, fmt.Errorf("%s: static_server must be caddy or nginx, got %q", FileName, cfg.StaticServer)
+	}
+	switch cfg.BaseVariant {
+	case "", app.VariantDebianSlim, app.VariantAlpine, app.VariantDistroless:
+	default:
+		return nil, fmt.Errorf("%s: base_variant must be %s, %s or %s, got %q", FileName, app.VariantDebianSlim, app.VariantAlpine, app.VariantDistroless, cfg.BaseVariant)
+	}
+
+	return &cfg, nil
+}
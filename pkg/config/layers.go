@@ -0,0 +1,322 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Flags are the settings given as CLI flags, empty when not given
+type Flags struct {
+	InstallCommand string
+	BuildCommand   string
+	StartCommand   string
+	Process        string
+	Packages       []string
+	BaseVariant    string
+	StaticServer   string
+	OutputDir      string
+	SPA            bool
+	NoSPA          bool
+
+	// BuildEnv are KEY=value pairs, or KEY to use the current environment
+	BuildEnv []string
+}
+
+// setting is a value and the layer it came from
+type setting struct {
+	value  string
+	source string
+}
+
+// fromEnv returns the setting of an environment variable
+func fromEnv(name string) setting {
+	return setting{os.Getenv(name), name}
+}
+
+// Apply layers the settings over the plan, each taken from the highest priority layer
+// that sets it: CLI flags > COOLPACK_* environment variables > coolpack.toml > the plan
+// (detected or loaded). Packages and build env vars of every layer are merged.
+// cfg is nil when the project has no coolpack.toml
+func Apply(plan *app.Plan, flags Flags, cfg *Config) error {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	// Process selection comes first, so an overridden start command still wins
+	process := first(setting{flags.Process, "--process"}, fromEnv("COOLPACK_PROCESS"), setting{cfg.Process, FileName})
+	if err := applyProcess(plan, process); err != nil {
+		return err
+	}
+
+	apply(plan, "install_command", &plan.InstallCommand,
+		setting{flags.InstallCommand, "--install-cmd"}, fromEnv("COOLPACK_INSTALL_CMD"), setting{cfg.InstallCommand, FileName})
+	apply(plan, "build_command", &plan.BuildCommand,
+		setting{flags.BuildCommand, "--build-cmd"}, fromEnv("COOLPACK_BUILD_CMD"), setting{cfg.BuildCommand, FileName})
+	apply(plan, "start_command", &plan.StartCommand,
+		setting{flags.StartCommand, "--start-cmd"}, fromEnv("COOLPACK_START_CMD"), setting{cfg.StartCommand, FileName})
+
+	// Node.js version of the app, or of the asset build next to another language
+	if field, target := nodeVersionField(plan); target != nil {
+		apply(plan, field, target,
+			nodeVersion(fromEnv("COOLPACK_NODE_VERSION")), nodeVersion(fromEnv("NODE_VERSION")), nodeVersion(setting{cfg.NodeVersion, FileName}))
+	}
+
+	apply(plan, "runtime.base_image", &plan.Runtime.BaseImage,
+		fromEnv("COOLPACK_BASE_IMAGE"), setting{cfg.BaseImage, FileName})
+	apply(plan, "runtime.base_variant", &plan.Runtime.BaseVariant,
+		setting{flags.BaseVariant, "--base-variant"}, fromEnv("COOLPACK_BASE_VARIANT"), setting{cfg.BaseVariant, FileName})
+	if err := checkBaseVariant(plan); err != nil {
+		return err
+	}
+
+	// Default is caddy, which is handled in the generator
+	apply(plan, "static.server", &plan.Static.Server,
+		setting{flags.StaticServer, "--static-server"}, fromEnv("COOLPACK_STATIC_SERVER"), setting{cfg.StaticServer, FileName})
+
+	apply(plan, "static.output_dir", &plan.Static.OutputDir,
+		setting{flags.OutputDir, "--output-dir"}, fromEnv("COOLPACK_SPA_OUTPUT_DIR"), setting{cfg.OutputDir, FileName})
+
+	applySPA(plan, flags, cfg)
+	applyPackages(plan, flags.Packages, cfg.Packages)
+	applyBuildEnv(plan, flags.BuildEnv, cfg.BuildEnv)
+	return nil
+}
+
+// first returns the first setting that has a value
+func first(settings ...setting) setting {
+	for _, s := range settings {
+		if s.value != "" {
+			return s
+		}
+	}
+	return setting{}
+}
+
+// apply sets a plan field from the first setting that has a value
+func apply(plan *app.Plan, field string, target *string, settings ...setting) {
+	if s := first(settings...); s.value != "" {
+		*target = s.value
+		plan.SetSource(field, s.source)
+	}
+}
+
+// nodeVersion normalizes a Node.js version setting (v20 -> 20)
+func nodeVersion(s setting) setting {
+	s.value = strings.TrimPrefix(strings.TrimSpace(s.value), "v")
+	return s
+}
+
+// nodeVersionField returns the plan field holding the Node.js version, or nil if the plan has none
+func nodeVersionField(plan *app.Plan) (string, *string) {
+	switch {
+	case plan.Language == "nodejs":
+		return "language_version", &plan.LanguageVersion
+	case plan.Tools.NodeVersion != "":
+		return "tools.node_version", &plan.Tools.NodeVersion
+	}
+	return "", nil
+}
+
+// checkBaseVariant checks the base image variant, which an Alpine base image of a
+// Node.js app implies when none is set. The distroless variant selects the runner
+func checkBaseVariant(plan *app.Plan) error {
+	if plan.Runtime.BaseVariant == "" && plan.Provider == "node" && strings.Contains(plan.Runtime.BaseImage, "alpine") {
+		plan.Runtime.BaseVariant = app.VariantAlpine
+		plan.SetSource("runtime.base_variant", "runtime.base_image")
+	}
+
+	switch plan.Runtime.BaseVariant {
+	case "", app.VariantDebianSlim:
+	case app.VariantAlpine:
+		if plan.Provider != "node" {
+			return fmt.Errorf("base variant %s is only supported for Node.js apps (provider: %s)", app.VariantAlpine, plan.Provider)
+		}
+		// Native modules built against musl don't load on the Debian-based runner
+		if plan.Provider == "node" && plan.Runtime.Runner == "distroless" {
+			return fmt.Errorf("base variant %s can't be used with the distroless runner, which is Debian based", app.VariantAlpine)
+		}
+	case app.VariantDistroless:
+		if err := setDistrolessRunner(plan); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("base variant must be %s, %s or %s, got %q", app.VariantDebianSlim, app.VariantAlpine, app.VariantDistroless, plan.Runtime.BaseVariant)
+	}
+	return nil
+}
+
+// setDistrolessRunner runs a Node.js server app on the distroless runner, which the
+// distroless base variant selects (static output keeps its static file server)
+func setDistrolessRunner(plan *app.Plan) error {
+	switch {
+	case plan.Provider != "node":
+		return fmt.Errorf("base variant %s is only supported for Node.js apps (provider: %s)", app.VariantDistroless, plan.Provider)
+	case plan.Runtime.Name == "bun":
+		return fmt.Errorf("base variant %s needs the Node.js runtime, Bun apps use the oven/bun image", app.VariantDistroless)
+	case plan.Workspace.App != "":
+		return fmt.Errorf("base variant %s isn't supported for workspace apps", app.VariantDistroless)
+	case plan.Runtime.OutputType == "static":
+		return nil
+	}
+	plan.Runtime.Runner = "distroless"
+	plan.SetSource("runtime.runner", "runtime.base_variant")
+	return nil
+}
+
+// applyProcess makes a Procfile process type the image's start command
+func applyProcess(plan *app.Plan, process setting) error {
+	// A plan file written after the selection already starts the process
+	if process.value == "" || process.value == "web" || process.value == plan.Runtime.Process {
+		return nil
+	}
+
+	command, ok := plan.Processes[process.value]
+	if !ok {
+		return fmt.Errorf("process type %q not found in Procfile", process.value)
+	}
+
+	// Keep the web process available to the launcher
+	if plan.StartCommand != "" {
+		plan.Processes["web"] = plan.StartCommand
+	}
+	delete(plan.Processes, process.value)
+	plan.StartCommand = command
+	plan.SetSource("start_command", fmt.Sprintf("Procfile %s process", process.value))
+
+	plan.Runtime.Process = process.value
+	plan.SetSource("runtime.process", process.source)
+	return nil
+}
+
+// applySPA sets SPA mode
+// Priority: --no-spa/COOLPACK_NO_SPA > --spa/COOLPACK_SPA > coolpack.toml > auto-detected
+func applySPA(plan *app.Plan, flags Flags, cfg *Config) {
+	switch {
+	case flags.NoSPA || isTrue(os.Getenv("COOLPACK_NO_SPA")):
+		plan.Static.SPA = false
+		delete(plan.Provenance, "static.spa")
+	case flags.SPA:
+		plan.Static.SPA = true
+		plan.SetSource("static.spa", "--spa")
+	case isTrue(os.Getenv("COOLPACK_SPA")):
+		plan.Static.SPA = true
+		plan.SetSource("static.spa", "COOLPACK_SPA")
+	case cfg.SPA != nil:
+		plan.Static.SPA = *cfg.SPA
+		if *cfg.SPA {
+			plan.SetSource("static.spa", FileName)
+		} else {
+			delete(plan.Provenance, "static.spa")
+		}
+	}
+}
+
+// isTrue reports whether a boolean environment variable is set
+func isTrue(value string) bool {
+	return value == "true" || value == "1"
+}
+
+// applyPackages adds the custom APT packages of every layer to the plan's own
+func applyPackages(plan *app.Plan, flagPackages, filePackages []string) {
+	packages := append([]string{}, plan.Packages.Custom...)
+	var sources []string
+	if source := plan.Source("packages.custom"); source != "" {
+		sources = append(sources, source)
+	}
+
+	if len(filePackages) > 0 {
+		packages = append(packages, filePackages...)
+		sources = append(sources, FileName)
+	}
+	if len(flagPackages) > 0 {
+		packages = append(packages, flagPackages...)
+		sources = append(sources, "--packages")
+	}
+	// Comma-separated
+	if env := os.Getenv("COOLPACK_PACKAGES"); env != "" {
+		sources = append(sources, "COOLPACK_PACKAGES")
+		for _, pkg := range strings.Split(env, ",") {
+			packages = append(packages, strings.TrimSpace(pkg))
+		}
+	}
+
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		if pkg != "" && !seen[pkg] {
+			seen[pkg] = true
+			unique = append(unique, pkg)
+		}
+	}
+	if len(unique) == 0 {
+		return
+	}
+
+	plan.Packages.Custom = unique
+	plan.SetSource("packages.custom", strings.Join(dedupe(sources), ", "))
+}
+
+// applyBuildEnv merges the build-time environment variables of every layer into the plan's own
+// A variable set by --build-env wins over coolpack.toml, which wins over the plan
+func applyBuildEnv(plan *app.Plan, flagEnv []string, fileEnv map[string]string) {
+	var sources []string
+	if source := plan.Source("build_env"); source != "" {
+		sources = append(sources, source)
+	}
+
+	buildEnv := make(map[string]string)
+	for key, value := range plan.BuildEnv {
+		buildEnv[key] = value
+	}
+	if len(fileEnv) > 0 {
+		for key, value := range fileEnv {
+			buildEnv[key] = value
+		}
+		sources = append(sources, FileName)
+	}
+	if parsed := parseEnvVars(flagEnv); len(parsed) > 0 {
+		for key, value := range parsed {
+			buildEnv[key] = value
+		}
+		sources = append(sources, "--build-env")
+	}
+
+	if len(buildEnv) == 0 {
+		return
+	}
+	plan.BuildEnv = buildEnv
+	plan.SetSource("build_env", strings.Join(dedupe(sources), ", "))
+}
+
+// parseEnvVars parses environment variable arguments
+// Supports KEY=value format or KEY (pulls from current environment)
+func parseEnvVars(envArgs []string) map[string]string {
+	result := make(map[string]string)
+	for _, env := range envArgs {
+		if key, value, ok := strings.Cut(env, "="); ok {
+			result[key] = value
+		} else if value, exists := os.LookupEnv(env); exists {
+			// KEY only - pull from current environment
+			result[env] = value
+		}
+	}
+	return result
+}
+
+// dedupe removes repeated sources, which a plan file applied again already lists
+func dedupe(sources []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, source := range sources {
+		for _, part := range strings.Split(source, ", ") {
+			if !seen[part] {
+				seen[part] = true
+				unique = append(unique, part)
+			}
+		}
+	}
+	return unique
+}
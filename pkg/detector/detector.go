@@ -1,16 +1,39 @@
 package detector
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/deno"
+	"github.com/coollabsio/coolpack/pkg/providers/dockerfile"
+	"github.com/coollabsio/coolpack/pkg/providers/dotnet"
+	"github.com/coollabsio/coolpack/pkg/providers/elixir"
+	"github.com/coollabsio/coolpack/pkg/providers/golang"
+	"github.com/coollabsio/coolpack/pkg/providers/hugo"
+	"github.com/coollabsio/coolpack/pkg/providers/java"
+	"github.com/coollabsio/coolpack/pkg/providers/jekyll"
+	"github.com/coollabsio/coolpack/pkg/providers/mkdocs"
 	"github.com/coollabsio/coolpack/pkg/providers/node"
+	"github.com/coollabsio/coolpack/pkg/providers/php"
+	"github.com/coollabsio/coolpack/pkg/providers/python"
+	"github.com/coollabsio/coolpack/pkg/providers/ruby"
+	"github.com/coollabsio/coolpack/pkg/providers/rust"
+	"github.com/coollabsio/coolpack/pkg/providers/static"
 )
 
 // Detector handles application detection using registered providers
 type Detector struct {
 	path      string
 	providers []Provider
+
+	// provider forces a provider by name, skipping ranking
+	provider string
+
+	// app selects one app of a monorepo (e.g., apps/web)
+	app string
 }
 
 // New creates a new Detector for the given path
@@ -27,34 +50,214 @@ func New(path string) *Detector {
 }
 
 // registerProviders adds all available providers to the detector
+// Providers are ranked by detection confidence; registration order breaks ties
 func (d *Detector) registerProviders() {
+	// Dockerfile provider (opting in means the project's own Dockerfile wins)
+	d.providers = append(d.providers, dockerfile.New())
+
+	// Jekyll provider (outranks Ruby: Jekyll sites are Bundler projects)
+	d.providers = append(d.providers, jekyll.New())
+
+	// Ruby provider (before Node.js on ties: Rails apps ship a package.json for assets)
+	d.providers = append(d.providers, ruby.New())
+
+	// PHP provider (before Node.js on ties: Laravel ships a package.json for Vite)
+	d.providers = append(d.providers, php.New())
+
+	// Java provider (before Node.js on ties: frontend-maven-plugin builds use a root package.json)
+	d.providers = append(d.providers, java.New())
+
+	// Deno provider (outranks Node.js: Deno 2 projects may also have a package.json)
+	d.providers = append(d.providers, deno.New())
+
+	// Hugo provider (outranks Node.js and Go: sites use package.json for PostCSS and go.mod for Hugo Modules)
+	d.providers = append(d.providers, hugo.New())
+
 	// Node.js provider
 	d.providers = append(d.providers, node.New())
 
-	// TODO: Add more providers here (python, go, rust, etc.)
+	// MkDocs provider (outranks Python: docs sites keep their plugins in requirements.txt)
+	d.providers = append(d.providers, mkdocs.New())
+
+	// Python provider
+	d.providers = append(d.providers, python.New())
+
+	// Go provider
+	d.providers = append(d.providers, golang.New())
+
+	// Rust provider
+	d.providers = append(d.providers, rust.New())
+
+	// .NET provider
+	d.providers = append(d.providers, dotnet.New())
+
+	// Elixir provider
+	d.providers = append(d.providers, elixir.New())
+
+	// Static site provider (weakest: any project can have an index.html in public/)
+	d.providers = append(d.providers, static.New())
+
+	// TODO: Add more providers here
 }
 
-// Detect runs detection using all registered providers and returns a plan
-func (d *Detector) Detect() (*Plan, error) {
-	ctx := app.NewContext(d.path)
+// Candidate is a provider that matched (or failed to inspect) the application
+type Candidate struct {
+	Provider string `json:"provider"`
+	app.Detection
 
-	// Load environment variables that might influence detection
-	ctx.Env = loadRelevantEnvVars()
+	// Error is set when the provider failed to inspect the application
+	Error string `json:"error,omitempty"`
+}
+
+// SetProvider forces the provider to use instead of the highest ranked one
+// Takes precedence over COOLPACK_PROVIDER
+func (d *Detector) SetProvider(name string) {
+	d.provider = name
+}
+
+// SetApp selects one app of a monorepo by path or package name
+// Takes precedence over COOLPACK_APP
+func (d *Detector) SetApp(selector string) {
+	d.app = selector
+}
+
+// Apps lists the deployable apps of a monorepo, or nil if the project isn't one
+func (d *Detector) Apps() ([]node.WorkspaceApp, error) {
+	return node.DiscoverApps(d.newContext())
+}
 
-	// Try each provider in order
+// Rank runs detection with all registered providers and returns the candidates,
+// highest confidence first. Ties keep registration order
+func (d *Detector) Rank() []Candidate {
+	return d.rank(d.newContext())
+}
+
+func (d *Detector) rank(ctx *app.Context) []Candidate {
+	var candidates []Candidate
 	for _, provider := range d.providers {
-		detected, err := provider.Detect(ctx)
+		detection, err := provider.Detect(ctx)
+		if err == nil && !detection.Matched() {
+			continue
+		}
+		candidate := Candidate{Provider: provider.Name(), Detection: detection}
 		if err != nil {
-			// Log error but continue to next provider
+			candidate.Error = err.Error()
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+	return candidates
+}
+
+// Detect runs detection using all registered providers and returns the plan of
+// the highest ranked one (or the forced provider). Returns nil if nothing matched
+func (d *Detector) Detect() (*Plan, error) {
+	ctx := d.newContext()
+
+	provider, reason, err := d.selectProvider(ctx)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+
+	plan, err := provider.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan.SchemaVersion = app.SchemaVersion
+	plan.SetSource("provider", reason)
+	applyProcfile(ctx, plan)
+	return plan, nil
+}
+
+// selectProvider returns the forced provider, or the highest ranked candidate, and why it was selected
+// Providers that failed to inspect the application are only reported when nothing else matched
+func (d *Detector) selectProvider(ctx *app.Context) (Provider, string, error) {
+	forced, reason := d.provider, "--provider"
+	if forced == "" {
+		forced, reason = ctx.Env["COOLPACK_PROVIDER"], "COOLPACK_PROVIDER"
+	}
+	if forced != "" {
+		provider := d.lookup(forced)
+		if provider == nil {
+			return nil, "", fmt.Errorf("unknown provider %q (available: %s)", forced, strings.Join(d.names(), ", "))
+		}
+		return provider, reason, nil
+	}
+
+	var failed []string
+	for _, candidate := range d.rank(ctx) {
+		if candidate.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", candidate.Provider, candidate.Error))
 			continue
 		}
+		reason := fmt.Sprintf("highest confidence %d (%s)", candidate.Confidence, strings.Join(candidate.Reasons, ", "))
+		return d.lookup(candidate.Provider), reason, nil
+	}
+	if len(failed) > 0 {
+		return nil, "", fmt.Errorf("no provider could inspect the application (%s)", strings.Join(failed, "; "))
+	}
+	return nil, "", nil
+}
 
-		if detected {
-			return provider.Plan(ctx)
+// lookup returns the registered provider with the given name
+func (d *Detector) lookup(name string) Provider {
+	for _, provider := range d.providers {
+		if provider.Name() == name {
+			return provider
 		}
 	}
+	return nil
+}
 
-	return nil, nil
+// names returns the names of all registered providers
+func (d *Detector) names() []string {
+	names := make([]string, 0, len(d.providers))
+	for _, provider := range d.providers {
+		names = append(names, provider.Name())
+	}
+	return names
+}
+
+// newContext creates the detection context for the application path
+func (d *Detector) newContext() *app.Context {
+	ctx := app.NewContext(d.path)
+
+	// Load environment variables that might influence detection
+	ctx.Env = loadRelevantEnvVars()
+	if d.app != "" {
+		ctx.Env["COOLPACK_APP"] = d.app
+	}
+
+	return ctx
+}
+
+// applyProcfile uses the Procfile's web process as the start command and records
+// the other process types. Static sites are skipped, since they have nothing to start,
+// and so are projects built from their own Dockerfile, which sets its own CMD
+func applyProcfile(ctx *app.Context, plan *Plan) {
+	processes := ctx.ReadProcfile()
+	if len(processes) == 0 || plan.Provider == "dockerfile" {
+		return
+	}
+	if plan.Runtime.OutputType == "static" {
+		return
+	}
+
+	plan.DetectedFiles = append(plan.DetectedFiles, "Procfile")
+	for _, process := range processes {
+		if process.Name == "web" {
+			plan.StartCommand = process.Command
+			plan.SetSource("start_command", "Procfile web process")
+			continue
+		}
+		if plan.Processes == nil {
+			plan.Processes = make(map[string]string)
+		}
+		plan.Processes[process.Name] = process.Command
+	}
 }
 
 // loadRelevantEnvVars loads environment variables that influence detection
@@ -70,7 +273,37 @@ func loadRelevantEnvVars() map[string]string {
 		// Image and version overrides
 		"COOLPACK_BASE_IMAGE",
 		"COOLPACK_NODE_VERSION",
+		"COOLPACK_PYTHON_VERSION",
+		"COOLPACK_GO_VERSION",
+		"COOLPACK_RUST_VERSION",
+		"COOLPACK_RUBY_VERSION",
+		"COOLPACK_PHP_VERSION",
+		"COOLPACK_JAVA_VERSION",
+		"COOLPACK_DOTNET_VERSION",
+		"COOLPACK_ELIXIR_VERSION",
+		"COOLPACK_ERLANG_VERSION",
+		"COOLPACK_DENO_VERSION",
+		"COOLPACK_HUGO_VERSION",
+		"COOLPACK_MKDOCS_VERSION",
 		"COOLPACK_SPA_OUTPUT_DIR",
+		// Node.js runner (node or distroless)
+		"COOLPACK_NODE_RUNNER",
+		// Go build settings
+		"COOLPACK_GO_MAIN_PACKAGE",
+		"COOLPACK_GO_RUNNER",
+		// Rust build settings
+		"COOLPACK_RUST_BIN",
+		// PHP runner (frankenphp or fpm)
+		"COOLPACK_PHP_RUNNER",
+		// .NET project to publish
+		"COOLPACK_DOTNET_PROJECT",
+		// Provider selection (skips ranking)
+		"COOLPACK_PROVIDER",
+		// Monorepo app selection
+		"COOLPACK_APP",
+		// Existing Dockerfile passthrough
+		"COOLPACK_USE_DOCKERFILE",
+		"COOLPACK_DOCKERFILE",
 		// Static server (caddy or nginx)
 		"COOLPACK_STATIC_SERVER",
 		// SPA mode
@@ -78,6 +311,7 @@ func loadRelevantEnvVars() map[string]string {
 		"COOLPACK_NO_SPA",
 		// Legacy support
 		"NODE_VERSION",
+		"PYTHON_VERSION",
 	}
 
 	for _, v := range envVars {
@@ -13,8 +13,9 @@ type Provider interface {
 	Name() string
 
 	// Detect checks if this provider can handle the application at the given path
-	// Returns true if the provider detected a matching application
-	Detect(ctx *app.Context) (bool, error)
+	// Returns how confident the provider is (app.ConfidenceNone if it doesn't apply)
+	// and the reasons, so the detector can rank providers against each other
+	Detect(ctx *app.Context) (app.Detection, error)
 
 	// Plan generates a build plan for the detected application
 	Plan(ctx *app.Context) (*app.Plan, error)
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateDenoDockerfile() (string, error) {
+	var sb strings.Builder
+
+	denoVersion := g.plan.LanguageVersion
+	if denoVersion == "" {
+		denoVersion = "latest"
+	}
+
+	outputType := "server"
+	if ot := g.plan.Runtime.OutputType; ot != "" {
+		outputType = ot
+	}
+
+	// Determine base image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("denoland/deno:%s", denoVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, outputType))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV DENO_DIR=/deno-dir \\\n")
+	sb.WriteString("    DENO_NO_UPDATE_CHECK=1\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Copy config files first (for better caching), then install
+	// Modules are downloaded into a cache mount and copied into DENO_DIR,
+	// since the runner needs them but a mount isn't part of the layer
+	sb.WriteString("COPY deno.json* deno.lock* package.json* ./\n\n")
+	if g.plan.InstallCommand != "" {
+		sb.WriteString("RUN --mount=type=cache,target=/deno-cache \\\n")
+		sb.WriteString(fmt.Sprintf("    DENO_DIR=/deno-cache %s && \\\n", g.plan.InstallCommand))
+		sb.WriteString("    cp -a /deno-cache/. /deno-dir/\n\n")
+	}
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Build if there's a build command
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s\n\n", g.plan.BuildCommand))
+	}
+
+	if outputType == "static" {
+		g.writeStaticServerStage(&sb, builderOutput(g.getStaticOutputDir()))
+		return sb.String(), nil
+	}
+
+	// Pre-cache remote modules imported by the entry point so startup doesn't download them
+	if entrypoint := g.plan.Deno.Entrypoint; entrypoint != "" {
+		sb.WriteString(fmt.Sprintf("RUN deno cache %s\n\n", entrypoint))
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS runner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	sb.WriteString("ENV DENO_DIR=/deno-dir \\\n")
+	sb.WriteString("    DENO_NO_UPDATE_CHECK=1 \\\n")
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy module cache and application
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /deno-dir /deno-dir\n")
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app /app\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"deno\", \"run\", \"-A\", \"main.ts\"]\n")
+	}
+
+	return sb.String(), nil
+}
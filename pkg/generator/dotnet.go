@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateDotnetDockerfile() (string, error) {
+	var sb strings.Builder
+
+	dotnetVersion := g.plan.LanguageVersion
+	if dotnetVersion == "" {
+		dotnetVersion = "10.0"
+	}
+
+	// Determine builder image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("mcr.microsoft.com/dotnet/sdk:%s", dotnetVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// ASP.NET Core apps need the aspnet runtime, console apps the plain runtime
+	runtime := g.plan.Runtime.Name
+	if runtime == "" {
+		runtime = "aspnet"
+	}
+
+	project := g.plan.DotNet.Project
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Project: %s, Output: server\n\n", g.plan.Provider, project))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV DOTNET_CLI_TELEMETRY_OPTOUT=1 \\\n")
+	sb.WriteString("    DOTNET_NOLOGO=1\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Restore and publish with the NuGet package cache mounted
+	cacheMount := "--mount=type=cache,target=/root/.nuget/packages "
+	if g.plan.InstallCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+	}
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.BuildCommand))
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM mcr.microsoft.com/dotnet/%s:%s AS runner\n", runtime, dotnetVersion))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Runtime libraries
+	g.writeRuntimeAptInstall(&sb)
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	sb.WriteString("ENV DOTNET_CLI_TELEMETRY_OPTOUT=1 \\\n")
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy only the published output
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app/publish /app\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateElixirDockerfile() (string, error) {
+	var sb strings.Builder
+
+	elixirVersion := g.plan.LanguageVersion
+	if elixirVersion == "" {
+		elixirVersion = "1.18"
+	}
+	erlangVersion := g.plan.Elixir.ErlangVersion
+	if erlangVersion == "" {
+		erlangVersion = "27"
+	}
+
+	// Determine builder image (COOLPACK_BASE_IMAGE overrides default)
+	// The official images are Debian bookworm based, matching the runner
+	baseImage := fmt.Sprintf("elixir:%s-otp-%s-slim", elixirVersion, erlangVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	release := g.plan.Elixir.Release
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Release: %s, Output: server\n\n", g.plan.Provider, g.plan.Framework, release))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV MIX_ENV=prod\n\n")
+
+	// Install APT packages for compiling NIFs
+	g.writeAptInstall(&sb)
+
+	// Node.js for npm-based Phoenix assets
+	g.writeNodeToolchain(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	sb.WriteString("RUN mix local.hex --force && mix local.rebar --force\n\n")
+
+	// Fetch dependencies with the Hex package cache mounted
+	// Umbrella projects need every app's mix.exs, so they copy the whole tree first
+	cacheMount := "--mount=type=cache,target=/root/.hex/packages "
+	if g.plan.Elixir.IsUmbrella {
+		sb.WriteString("COPY . .\n\n")
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+	} else {
+		sb.WriteString("COPY mix.exs mix.lock* ./\n\n")
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		sb.WriteString("COPY . .\n\n")
+	}
+
+	// Build assets and assemble the release
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s\n\n", g.plan.BuildCommand))
+	}
+
+	// Production stage - the release bundles ERTS, so only system libraries are needed
+	sb.WriteString("FROM debian:bookworm-slim AS runner\n")
+	sb.WriteString("WORKDIR /app\n\n")
+
+	g.writeRuntimeAptInstall(&sb, "libstdc++6", "openssl", "libncurses6", "ca-certificates")
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	sb.WriteString("ENV LANG=C.UTF-8 \\\n")
+	sb.WriteString("    MIX_ENV=prod \\\n")
+	if g.plan.Framework == "phoenix" {
+		// Releases don't start the Phoenix endpoint unless PHX_SERVER is set
+		sb.WriteString("    PHX_SERVER=true \\\n")
+	}
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy only the release
+	sb.WriteString(fmt.Sprintf("COPY --from=builder --chown=cooluser:coolgroup /app/_build/prod/rel/%s /app\n\n", release))
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	}
+
+	return sb.String(), nil
+}
@@ -2,6 +2,9 @@ package generator
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/coollabsio/coolpack/pkg/app"
@@ -22,6 +25,28 @@ func (g *Generator) GenerateDockerfile() (string, error) {
 	switch g.plan.Provider {
 	case "node":
 		return g.generateNodeDockerfile()
+	case "python", "mkdocs":
+		return g.generatePythonDockerfile()
+	case "go":
+		return g.generateGoDockerfile()
+	case "rust":
+		return g.generateRustDockerfile()
+	case "ruby", "jekyll":
+		return g.generateRubyDockerfile()
+	case "php":
+		return g.generatePHPDockerfile()
+	case "java":
+		return g.generateJavaDockerfile()
+	case "dotnet":
+		return g.generateDotnetDockerfile()
+	case "elixir":
+		return g.generateElixirDockerfile()
+	case "deno":
+		return g.generateDenoDockerfile()
+	case "static":
+		return g.generateStaticSiteDockerfile()
+	case "hugo":
+		return g.generateHugoDockerfile()
 	default:
 		return "", fmt.Errorf("unsupported provider: %s", g.plan.Provider)
 	}
@@ -36,13 +61,13 @@ func (g *Generator) generateNodeDockerfile() (string, error) {
 	}
 
 	outputType := "server"
-	if ot, ok := g.plan.Metadata["output_type"].(string); ok {
+	if ot := g.plan.Runtime.OutputType; ot != "" {
 		outputType = ot
 	}
 
 	// Determine base image variant (COOLPACK_BASE_IMAGE overrides default)
 	var baseImage string
-	if customBase, ok := g.plan.Metadata["base_image"].(string); ok && customBase != "" {
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
 		baseImage = customBase
 	} else if g.plan.PackageManager == "bun" {
 		// Use official bun image when bun is the package manager
@@ -50,11 +75,18 @@ func (g *Generator) generateNodeDockerfile() (string, error) {
 		if g.plan.PackageManagerVersion != "" {
 			bunVersion = g.plan.PackageManagerVersion
 		}
-		if bunVersion != "latest" {
+		switch {
+		case g.isAlpine() && bunVersion != "latest":
+			baseImage = fmt.Sprintf("oven/bun:%s-alpine", bunVersion)
+		case g.isAlpine():
+			baseImage = "oven/bun:alpine"
+		case bunVersion != "latest":
 			baseImage = fmt.Sprintf("oven/bun:%s-slim", bunVersion)
-		} else {
+		default:
 			baseImage = "oven/bun:latest"
 		}
+	} else if g.isAlpine() {
+		baseImage = fmt.Sprintf("node:%s-alpine", nodeVersion)
 	} else {
 		baseImage = fmt.Sprintf("node:%s-slim", nodeVersion)
 	}
@@ -62,18 +94,24 @@ func (g *Generator) generateNodeDockerfile() (string, error) {
 	// Write Dockerfile with BuildKit syntax for cache mounts
 	sb.WriteString("# syntax=docker/dockerfile:1\n")
 	sb.WriteString("# Generated by Coolpack\n")
-	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, outputType))
+	if runner := g.plan.Runtime.Runner; runner != "" && outputType != "static" {
+		sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Runner: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, runner, outputType))
+	} else {
+		sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, outputType))
+	}
 
-	if outputType == "static" {
+	if app := g.plan.Workspace.App; app != "" {
+		g.writeWorkspaceDockerfile(&sb, baseImage, outputType)
+	} else if outputType == "static" {
 		g.writeStaticDockerfile(&sb, baseImage)
-	} else {
-		g.writeServerDockerfile(&sb, baseImage)
+	} else if err := g.writeServerDockerfile(&sb, baseImage, nodeVersion); err != nil {
+		return "", err
 	}
 
 	return sb.String(), nil
 }
 
-func (g *Generator) writeServerDockerfile(sb *strings.Builder, baseImage string) {
+func (g *Generator) writeServerDockerfile(sb *strings.Builder, baseImage, nodeVersion string) error {
 	pm := g.plan.PackageManager
 	if pm == "" {
 		pm = "npm"
@@ -109,21 +147,29 @@ func (g *Generator) writeServerDockerfile(sb *strings.Builder, baseImage string)
 	}
 
 	// Production stage
+	if g.plan.Runtime.Runner == "distroless" {
+		return g.writeDistrolessNodeRunnerStage(sb, pm, nodeVersion)
+	}
 	sb.WriteString(fmt.Sprintf("FROM %s AS runner\n", baseImage))
 	sb.WriteString("WORKDIR /app\n\n")
 
+	// Install the libraries native dependencies load at runtime
+	g.writeRuntimeAptInstall(sb)
+
 	// Install package manager if not npm
 	g.writePackageManagerInstall(sb, pm)
 
 	// Create non-root user
-	sb.WriteString("RUN groupadd --gid 1001 coolgroup &&\\\n")
-	sb.WriteString("    useradd --uid 1001 --gid 1001 cooluser\n\n")
+	g.writeCreateUser(sb)
 
 	// Set production environment (build envs are NOT included - pass at runtime via docker run -e)
 	sb.WriteString("ENV NODE_ENV=production\n\n")
 
 	// Copy built application
-	g.writeServerCopyStatements(sb, pm)
+	g.writeServerCopyStatements(sb, pm, "")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(sb)
 
 	// Set ownership and switch to non-root user
 	sb.WriteString("RUN chown -R cooluser:coolgroup /app\n")
@@ -138,6 +184,40 @@ func (g *Generator) writeServerDockerfile(sb *strings.Builder, baseImage string)
 	} else {
 		sb.WriteString("CMD [\"node\", \"index.js\"]\n")
 	}
+	return nil
+}
+
+// writeDistrolessNodeRunnerStage writes a runner stage based on distroless/nodejs, which
+// has no shell or package manager and runs as nonroot
+// Its entrypoint is node, so the start command must be a direct node invocation
+func (g *Generator) writeDistrolessNodeRunnerStage(sb *strings.Builder, pm, nodeVersion string) error {
+	args := []string{"index.js"}
+	if command := g.plan.StartCommand; command != "" {
+		fields := strings.Fields(command)
+		if fields[0] != "node" || len(fields) < 2 || strings.ContainsAny(command, "$&|;<>'\"`") {
+			return fmt.Errorf("the distroless runner has no shell or package manager, start command %q must run node directly (e.g. COOLPACK_START_CMD=\"node dist/index.js\")", command)
+		}
+		args = fields[1:]
+	}
+
+	major, _, _ := strings.Cut(nodeVersion, ".")
+	sb.WriteString(fmt.Sprintf("FROM gcr.io/distroless/nodejs%s-debian12:nonroot AS runner\n", major))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV NODE_ENV=production\n\n")
+
+	g.writeServerCopyStatements(sb, pm, "nonroot:nonroot")
+
+	sb.WriteString("USER nonroot:nonroot\n\n")
+
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = fmt.Sprintf("\"%s\"", arg)
+	}
+	sb.WriteString(fmt.Sprintf("CMD [%s]\n", strings.Join(quoted, ", ")))
+	return nil
 }
 
 func (g *Generator) writeStaticDockerfile(sb *strings.Builder, baseImage string) {
@@ -175,22 +255,31 @@ func (g *Generator) writeStaticDockerfile(sb *strings.Builder, baseImage string)
 		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", buildCacheMount, g.plan.BuildCommand))
 	}
 
+	g.writeStaticServerStage(sb, builderOutput(g.getStaticOutputDir()))
+}
+
+// builderOutput returns the COPY source for a directory built in the builder stage
+func builderOutput(outputDir string) string {
+	return fmt.Sprintf("--from=builder /app/%s", outputDir)
+}
+
+// writeStaticServerStage writes the runner stage serving static files
+// source is the COPY source of the files: a builder path or a build context directory
+func (g *Generator) writeStaticServerStage(sb *strings.Builder, source string) {
 	// Determine static server (caddy is default, nginx is option)
 	staticServer := "caddy"
-	if ss, ok := g.plan.Metadata["static_server"].(string); ok && ss != "" {
+	if ss := g.plan.Static.Server; ss != "" {
 		staticServer = ss
 	}
 
-	outputDir := g.getStaticOutputDir()
-
 	if staticServer == "nginx" {
-		g.writeNginxStaticStage(sb, outputDir)
+		g.writeNginxStaticStage(sb, source)
 	} else {
-		g.writeCaddyStaticStage(sb, outputDir)
+		g.writeCaddyStaticStage(sb, source)
 	}
 }
 
-func (g *Generator) writeCaddyStaticStage(sb *strings.Builder, outputDir string) {
+func (g *Generator) writeCaddyStaticStage(sb *strings.Builder, source string) {
 	// Serve stage - use Caddy for static files (default)
 	sb.WriteString("FROM caddy:alpine AS runner\n\n")
 
@@ -199,7 +288,7 @@ func (g *Generator) writeCaddyStaticStage(sb *strings.Builder, outputDir string)
 	sb.WriteString("    adduser --system --uid 1001 -G coolgroup cooluser\n\n")
 
 	// Copy built static files
-	sb.WriteString(fmt.Sprintf("COPY --from=builder /app/%s /srv\n\n", outputDir))
+	sb.WriteString(fmt.Sprintf("COPY %s /srv\n\n", source))
 
 	// Add SPA Caddyfile if needed
 	if g.isSPA() {
@@ -224,7 +313,7 @@ func (g *Generator) writeCaddyStaticStage(sb *strings.Builder, outputDir string)
 	}
 }
 
-func (g *Generator) writeNginxStaticStage(sb *strings.Builder, outputDir string) {
+func (g *Generator) writeNginxStaticStage(sb *strings.Builder, source string) {
 	// Serve stage - use nginx for static files
 	sb.WriteString("FROM nginx:alpine AS runner\n\n")
 
@@ -241,7 +330,7 @@ func (g *Generator) writeNginxStaticStage(sb *strings.Builder, outputDir string)
 	sb.WriteString("    chown cooluser:coolgroup /var/run/nginx.pid\n\n")
 
 	// Copy built static files to nginx
-	sb.WriteString(fmt.Sprintf("COPY --from=builder /app/%s /usr/share/nginx/html\n\n", outputDir))
+	sb.WriteString(fmt.Sprintf("COPY %s /usr/share/nginx/html\n\n", source))
 
 	// Add SPA nginx config if needed
 	if g.isSPA() {
@@ -266,10 +355,7 @@ func (g *Generator) writeNginxStaticStage(sb *strings.Builder, outputDir string)
 
 // isSPA returns true if the application is a Single Page Application
 func (g *Generator) isSPA() bool {
-	if isSPA, ok := g.plan.Metadata["is_spa"].(bool); ok {
-		return isSPA
-	}
-	return false
+	return g.plan.Static.SPA
 }
 
 func (g *Generator) writePackageManagerInstall(sb *strings.Builder, pm string) {
@@ -288,7 +374,7 @@ func (g *Generator) writePackageManagerInstall(sb *strings.Builder, pm string) {
 	case "bun":
 		// bun is already installed when using oven/bun image
 		// Only install if using a custom base image (non-bun)
-		if customBase, ok := g.plan.Metadata["base_image"].(string); ok && customBase != "" && !strings.Contains(customBase, "bun") {
+		if customBase := g.plan.Runtime.BaseImage; customBase != "" && !strings.Contains(customBase, "bun") {
 			if g.plan.PackageManagerVersion != "" {
 				sb.WriteString(fmt.Sprintf("RUN npm install -g bun@%s\n\n", g.plan.PackageManagerVersion))
 			} else {
@@ -315,72 +401,58 @@ func (g *Generator) writeCopyPackageFiles(sb *strings.Builder, pm string) {
 	sb.WriteString("./\n\n")
 }
 
-func (g *Generator) writeServerCopyStatements(sb *strings.Builder, pm string) {
+func (g *Generator) writeServerCopyStatements(sb *strings.Builder, pm, owner string) {
 	framework := g.plan.Framework
 
+	// Runners without a shell can't chown, so the copies set the owner
+	from := "--from=builder"
+	if owner != "" {
+		from += " --chown=" + owner
+	}
+
 	// Copy node_modules for production
-	sb.WriteString("COPY --from=builder /app/node_modules ./node_modules\n")
+	sb.WriteString("COPY " + from + " /app/node_modules ./node_modules\n")
 
 	// Framework-specific copy statements
 	switch framework {
 	case "nextjs":
-		sb.WriteString("COPY --from=builder /app/.next ./.next\n")
-		sb.WriteString("COPY --from=builder /app/public ./public\n")
-		sb.WriteString("COPY --from=builder /app/package.json ./\n")
+		sb.WriteString("COPY " + from + " /app/.next ./.next\n")
+		sb.WriteString("COPY " + from + " /app/public ./public\n")
+		sb.WriteString("COPY " + from + " /app/package.json ./\n")
 	case "nuxt":
-		sb.WriteString("COPY --from=builder /app/.output ./.output\n")
+		sb.WriteString("COPY " + from + " /app/.output ./.output\n")
 	case "remix":
-		sb.WriteString("COPY --from=builder /app/build ./build\n")
-		sb.WriteString("COPY --from=builder /app/public ./public\n")
-		sb.WriteString("COPY --from=builder /app/package.json ./\n")
+		sb.WriteString("COPY " + from + " /app/build ./build\n")
+		sb.WriteString("COPY " + from + " /app/public ./public\n")
+		sb.WriteString("COPY " + from + " /app/package.json ./\n")
 	case "astro":
-		sb.WriteString("COPY --from=builder /app/dist ./dist\n")
+		sb.WriteString("COPY " + from + " /app/dist ./dist\n")
 	case "sveltekit":
-		sb.WriteString("COPY --from=builder /app/build ./build\n")
-		sb.WriteString("COPY --from=builder /app/package.json ./\n")
+		sb.WriteString("COPY " + from + " /app/build ./build\n")
+		sb.WriteString("COPY " + from + " /app/package.json ./\n")
 	case "solid-start", "tanstack-start":
-		sb.WriteString("COPY --from=builder /app/.output ./.output\n")
+		sb.WriteString("COPY " + from + " /app/.output ./.output\n")
 	default:
 		// Generic: copy everything
-		sb.WriteString("COPY --from=builder /app .\n")
+		sb.WriteString("COPY " + from + " /app .\n")
 	}
 	sb.WriteString("\n")
 }
 
+// getStaticOutputDir returns the directory the build writes static output to
+// Providers record the framework's default, which --output-dir and COOLPACK_SPA_OUTPUT_DIR replace
 func (g *Generator) getStaticOutputDir() string {
-	// Check for user override first (CLI flag or COOLPACK_SPA_OUTPUT_DIR env var)
-	if override, ok := g.plan.Metadata["output_dir_override"].(string); ok && override != "" {
-		return override
-	}
-
-	// Framework-specific defaults
-	framework := g.plan.Framework
-
-	switch framework {
-	case "nextjs":
-		return "out"
-	case "nuxt":
-		return ".output/public"
-	case "astro":
-		return "dist"
-	case "gatsby":
-		return "public"
-	case "vite", "create-react-app":
-		return "dist"
-	case "angular":
-		return "dist"
-	case "sveltekit":
-		return "build"
-	case "solid-start", "tanstack-start":
-		return ".output/public"
-	case "eleventy":
-		return "_site"
-	default:
-		return "dist"
+	if dir := g.plan.Static.OutputDir; dir != "" {
+		return dir
 	}
+	return "dist"
 }
 
 func (g *Generator) formatCmdCommand(cmd string) string {
+	if needsShell(cmd) {
+		return fmt.Sprintf("[\"sh\", \"-c\", %s]", strconv.Quote(cmd))
+	}
+
 	// Convert command string to JSON array format for CMD
 	parts := strings.Fields(cmd)
 	quoted := make([]string, len(parts))
@@ -390,6 +462,12 @@ func (g *Generator) formatCmdCommand(cmd string) string {
 	return "[" + strings.Join(quoted, ", ") + "]"
 }
 
+// needsShell reports whether a command uses shell features (e.g., $PORT expansion,
+// quoting, chaining), which exec form passes through verbatim
+func needsShell(cmd string) bool {
+	return strings.ContainsAny(cmd, "$&|;<>'\"`")
+}
+
 // getCacheMount returns the BuildKit cache mount for the package manager (install phase)
 func (g *Generator) getCacheMount(pm string) string {
 	var caches []string
@@ -414,7 +492,7 @@ func (g *Generator) getCacheMount(pm string) string {
 	}
 
 	// Cypress cache (downloads happen during install)
-	if _, ok := g.plan.Metadata["has_cypress"].(bool); ok {
+	if g.plan.Cache.Cypress {
 		caches = append(caches, "--mount=type=cache,target=/root/.cache/Cypress")
 	}
 
@@ -426,35 +504,41 @@ func (g *Generator) getCacheMount(pm string) string {
 func (g *Generator) getBuildCacheMount() string {
 	var caches []string
 
+	// Workspace apps keep their build caches in the app directory
+	appDir := "/app"
+	if workdir := g.plan.Workspace.Workdir; workdir != "" {
+		appDir = path.Join("/app", workdir)
+	}
+
 	// Framework-specific build caches
 	switch g.plan.Framework {
 	case "nextjs":
-		caches = append(caches, "--mount=type=cache,target=/app/.next/cache")
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/.next/cache", appDir))
 	case "remix", "react-router":
-		caches = append(caches, "--mount=type=cache,target=/app/.cache")
-		caches = append(caches, "--mount=type=cache,target=/app/.react-router")
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/.cache", appDir))
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/.react-router", appDir))
 	case "vite", "tanstack-start":
-		caches = append(caches, "--mount=type=cache,target=/app/node_modules/.vite")
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/node_modules/.vite", appDir))
 	case "astro":
-		caches = append(caches, "--mount=type=cache,target=/app/node_modules/.astro")
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/node_modules/.astro", appDir))
 	case "nuxt":
-		caches = append(caches, "--mount=type=cache,target=/app/node_modules/.cache")
+		caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/node_modules/.cache", appDir))
 	}
 
 	// Default node_modules/.cache for all frameworks (webpack, babel, eslint, etc.)
 	caches = append(caches, "--mount=type=cache,target=/app/node_modules/.cache")
 
 	// Moon repo cache if detected
-	if _, ok := g.plan.Metadata["has_moon"].(bool); ok {
+	if g.plan.Cache.Moon {
 		caches = append(caches, "--mount=type=cache,target=/app/.moon/cache")
 	}
 
 	// Custom cache directories from package.json
-	if customDirs, ok := g.plan.Metadata["cache_directories"].([]string); ok {
+	if customDirs := g.plan.Cache.Directories; len(customDirs) > 0 {
 		for _, dir := range customDirs {
 			// Ensure the path is within /app
 			if !strings.HasPrefix(dir, "/") {
-				caches = append(caches, fmt.Sprintf("--mount=type=cache,target=/app/%s", dir))
+				caches = append(caches, fmt.Sprintf("--mount=type=cache,target=%s/%s", appDir, dir))
 			}
 		}
 	}
@@ -512,15 +596,20 @@ func (g *Generator) getSortedEnvKeys(env map[string]string) []string {
 }
 
 // writeAptInstall writes APT package installation for native and custom packages
+// Alpine builders get apk packages, and libc6-compat for prebuilt glibc binaries (e.g., Next.js SWC)
 func (g *Generator) writeAptInstall(sb *strings.Builder) {
 	// Collect all packages: native (apt_packages) + custom (custom_packages)
 	var allPackages []string
 
-	if aptPackages, ok := g.plan.Metadata["apt_packages"].([]string); ok {
+	if g.isAlpine() {
+		allPackages = append(allPackages, "libc6-compat")
+	}
+
+	if aptPackages := g.plan.Packages.Apt; len(aptPackages) > 0 {
 		allPackages = append(allPackages, aptPackages...)
 	}
 
-	if customPackages, ok := g.plan.Metadata["custom_packages"].([]string); ok {
+	if customPackages := g.plan.Packages.Custom; len(customPackages) > 0 {
 		allPackages = append(allPackages, customPackages...)
 	}
 
@@ -528,27 +617,144 @@ func (g *Generator) writeAptInstall(sb *strings.Builder) {
 		return
 	}
 
+	// Add comment about what packages are being installed
+	if nativePkgs := g.plan.Packages.Native; len(nativePkgs) > 0 {
+		sb.WriteString(fmt.Sprintf("# Native dependencies detected: %s\n", strings.Join(nativePkgs, ", ")))
+	}
+	if customPkgs := g.plan.Packages.Custom; len(customPkgs) > 0 {
+		sb.WriteString(fmt.Sprintf("# Custom packages: %s\n", strings.Join(customPkgs, ", ")))
+	}
+
+	g.writePackageInstall(sb, allPackages)
+}
+
+// writeRuntimeAptInstall writes APT package installation for the runner stage
+// Installs the runtime packages from the plan plus any base packages the runner always needs
+func (g *Generator) writeRuntimeAptInstall(sb *strings.Builder, basePackages ...string) {
+	allPackages := append([]string{}, basePackages...)
+
+	if runtimePackages := g.plan.Packages.RuntimeApt; len(runtimePackages) > 0 {
+		allPackages = append(allPackages, runtimePackages...)
+	}
+
+	if len(allPackages) == 0 {
+		return
+	}
+
+	g.writePackageInstall(sb, allPackages)
+}
+
+// writePackageInstall writes the installation of system packages with the base image's
+// package manager: apt-get, or apk with the packages' Alpine names
+func (g *Generator) writePackageInstall(sb *strings.Builder, packages []string) {
+	if g.isAlpine() {
+		sb.WriteString("RUN apk add --no-cache \\\n")
+		unique := app.AlpinePackages(packages)
+		for i, pkg := range unique {
+			if i < len(unique)-1 {
+				sb.WriteString(fmt.Sprintf("    %s \\\n", pkg))
+			} else {
+				sb.WriteString(fmt.Sprintf("    %s\n\n", pkg))
+			}
+		}
+		return
+	}
+
 	// Deduplicate
 	seen := make(map[string]bool)
-	unique := make([]string, 0, len(allPackages))
-	for _, pkg := range allPackages {
+	unique := make([]string, 0, len(packages))
+	for _, pkg := range packages {
 		if !seen[pkg] {
 			seen[pkg] = true
 			unique = append(unique, pkg)
 		}
 	}
 
-	// Add comment about what packages are being installed
-	if nativePkgs, ok := g.plan.Metadata["native_packages"].([]string); ok && len(nativePkgs) > 0 {
-		sb.WriteString(fmt.Sprintf("# Native dependencies detected: %s\n", strings.Join(nativePkgs, ", ")))
-	}
-	if customPkgs, ok := g.plan.Metadata["custom_packages"].([]string); ok && len(customPkgs) > 0 {
-		sb.WriteString(fmt.Sprintf("# Custom packages: %s\n", strings.Join(customPkgs, ", ")))
-	}
-
 	sb.WriteString("RUN apt-get update && apt-get install -y --no-install-recommends \\\n")
 	for _, pkg := range unique {
 		sb.WriteString(fmt.Sprintf("    %s \\\n", pkg))
 	}
 	sb.WriteString("    && rm -rf /var/lib/apt/lists/*\n\n")
 }
+
+// writeCreateUser writes the creation of the non-root user the runner switches to
+// BusyBox (Alpine) has addgroup/adduser instead of groupadd/useradd
+func (g *Generator) writeCreateUser(sb *strings.Builder) {
+	if g.isAlpine() {
+		sb.WriteString("RUN addgroup --system --gid 1001 coolgroup && \\\n")
+		sb.WriteString("    adduser --system --uid 1001 -G coolgroup cooluser\n\n")
+		return
+	}
+	sb.WriteString("RUN groupadd --gid 1001 coolgroup &&\\\n")
+	sb.WriteString("    useradd --uid 1001 --gid 1001 cooluser\n\n")
+}
+
+// isAlpine reports whether the app builds on the Alpine base images
+func (g *Generator) isAlpine() bool {
+	return g.plan.Runtime.BaseVariant == app.VariantAlpine
+}
+
+// writeNodeToolchain copies Node.js into a non-Node builder stage for frontend asset builds
+// Uses the Node.js version and package manager of the plan's tools section
+func (g *Generator) writeNodeToolchain(sb *strings.Builder) {
+	nodeVersion := g.plan.Tools.NodeVersion
+	if nodeVersion == "" {
+		return
+	}
+
+	sb.WriteString("# Node.js for frontend asset builds\n")
+	sb.WriteString(fmt.Sprintf("COPY --from=node:%s-slim /usr/local/bin/ /usr/local/bin/\n", nodeVersion))
+	sb.WriteString(fmt.Sprintf("COPY --from=node:%s-slim /usr/local/lib/node_modules/ /usr/local/lib/node_modules/\n", nodeVersion))
+
+	switch g.plan.Tools.NodePackageManager {
+	case "yarn", "yarnberry", "pnpm":
+		sb.WriteString("RUN corepack enable\n")
+	case "bun":
+		sb.WriteString("COPY --from=oven/bun:1 /usr/local/bin/bun /usr/local/bin/bun\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writeProcessLauncher installs /usr/local/bin/coolpack-process when the Procfile declares
+// more than the web process, so one image can run any process type:
+// docker run <image> coolpack-process worker
+func (g *Generator) writeProcessLauncher(sb *strings.Builder) {
+	if len(g.plan.Processes) == 0 {
+		return
+	}
+
+	// The start command is the web process, unless another process type was selected
+	current := "web"
+	if process := g.plan.Runtime.Process; process != "" {
+		current = process
+	}
+
+	commands := make(map[string]string, len(g.plan.Processes)+1)
+	for name, command := range g.plan.Processes {
+		commands[name] = command
+	}
+	if g.plan.StartCommand != "" {
+		commands[current] = g.plan.StartCommand
+	}
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("COPY --chmod=755 <<\"EOF\" /usr/local/bin/coolpack-process\n")
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString("case \"$1\" in\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("  %s) exec sh -c %s ;;\n", name, shellQuote(commands[name])))
+	}
+	sb.WriteString(fmt.Sprintf("  *) echo \"unknown process type: $1 (available: %s)\" >&2; exit 1 ;;\n", strings.Join(names, ", ")))
+	sb.WriteString("esac\n")
+	sb.WriteString("EOF\n\n")
+}
+
+// shellQuote quotes a string for POSIX sh using single quotes
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateGoDockerfile() (string, error) {
+	var sb strings.Builder
+
+	goVersion := g.plan.LanguageVersion
+	if goVersion == "" {
+		goVersion = "1.25"
+	}
+
+	// Determine builder image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("golang:%s", goVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	runner := "distroless"
+	if r := g.plan.Runtime.Runner; r != "" {
+		runner = r
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Runner: %s, Output: server\n\n", g.plan.Provider, runner))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	sb.WriteString("ENV GOMODCACHE=/go/pkg/mod \\\n")
+	sb.WriteString("    GOCACHE=/root/.cache/go-build\n\n")
+
+	modCache := "--mount=type=cache,target=/go/pkg/mod "
+	buildCache := modCache + "--mount=type=cache,target=/root/.cache/go-build "
+
+	// Download modules first (for better caching)
+	if g.plan.InstallCommand != "" {
+		sb.WriteString("COPY go.mod go.sum* ./\n\n")
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", modCache, g.plan.InstallCommand))
+	}
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Build the binary
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", buildCache, g.plan.BuildCommand))
+	}
+
+	// Production stage
+	var err error
+	if runner == "scratch" {
+		err = g.writeScratchRunnerStage(&sb)
+	} else {
+		err = g.writeDistrolessStaticRunnerStage(&sb)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// writeDistrolessStaticRunnerStage writes a runner stage based on distroless/static,
+// which ships CA certificates, tzdata and a nonroot user
+func (g *Generator) writeDistrolessStaticRunnerStage(sb *strings.Builder) error {
+	sb.WriteString("FROM gcr.io/distroless/static-debian12:nonroot AS runner\n")
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV PORT=3000\n\n")
+
+	g.writeBinaryCopy(sb, "nonroot:nonroot")
+
+	sb.WriteString("USER nonroot:nonroot\n\n")
+
+	return g.writeBinaryCmd(sb, "distroless")
+}
+
+// writeScratchRunnerStage writes a runner stage based on an empty image
+// CA certificates and timezone data are copied from the builder
+func (g *Generator) writeScratchRunnerStage(sb *strings.Builder) error {
+	sb.WriteString("FROM scratch AS runner\n")
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("COPY --from=builder /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/\n")
+	sb.WriteString("COPY --from=builder /usr/share/zoneinfo /usr/share/zoneinfo\n\n")
+
+	sb.WriteString("ENV PORT=3000\n\n")
+
+	g.writeBinaryCopy(sb, "1001:1001")
+
+	// scratch has no /etc/passwd, so use a numeric non-root user
+	sb.WriteString("USER 1001:1001\n\n")
+
+	return g.writeBinaryCmd(sb, "scratch")
+}
+
+// writeBinaryCopy copies the compiled binary out of the builder stage
+func (g *Generator) writeBinaryCopy(sb *strings.Builder, owner string) {
+	sb.WriteString(fmt.Sprintf("COPY --from=builder --chown=%s /app/server /app/server\n\n", owner))
+}
+
+// writeBinaryCmd writes EXPOSE and CMD for a runner without a shell
+// Start commands that need a shell can't run there, so they are rejected
+func (g *Generator) writeBinaryCmd(sb *strings.Builder, runner string) error {
+	if cmd := g.plan.StartCommand; needsShell(cmd) {
+		return fmt.Errorf("the %s runner has no shell, start command %q can't use shell features such as $PORT expansion, quoting or chaining (the runner sets PORT=3000)", runner, cmd)
+	}
+
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"/app/server\"]\n")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateHugoDockerfile() (string, error) {
+	var sb strings.Builder
+
+	hugoVersion := g.plan.LanguageVersion
+	if hugoVersion == "" {
+		hugoVersion = "0.147.0"
+	}
+
+	// Determine base image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := "debian:bookworm-slim"
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: static\n\n", g.plan.Provider, g.plan.Framework))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV HUGO_ENVIRONMENT=production \\\n")
+	sb.WriteString("    HUGO_CACHEDIR=/root/.cache/hugo\n\n")
+
+	// Install curl and git
+	g.writeAptInstall(&sb)
+
+	// Hugo extended release binary for the target platform
+	sb.WriteString("ARG TARGETARCH\n")
+	sb.WriteString(fmt.Sprintf("RUN curl -fsSL https://github.com/gohugoio/hugo/releases/download/v%s/hugo_extended_%s_linux-${TARGETARCH}.tar.gz | \\\n", hugoVersion, hugoVersion))
+	sb.WriteString("    tar -xz -C /usr/local/bin hugo\n\n")
+
+	// Go toolchain for Hugo Modules
+	if goVersion := g.plan.Tools.GoVersion; goVersion != "" {
+		sb.WriteString(fmt.Sprintf("COPY --from=golang:%s /usr/local/go /usr/local/go\n", goVersion))
+		sb.WriteString("ENV PATH=\"/usr/local/go/bin:$PATH\"\n\n")
+	}
+
+	// Node.js for PostCSS/Tailwind pipelines
+	g.writeNodeToolchain(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Install Node.js dependencies
+	if g.plan.InstallCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", g.getCacheMount(g.plan.PackageManager), g.plan.InstallCommand))
+	}
+
+	// Build with the module and resource cache mounted
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=/root/.cache/hugo %s\n\n", g.plan.BuildCommand))
+	}
+
+	g.writeStaticServerStage(&sb, builderOutput(g.getStaticOutputDir()))
+
+	return sb.String(), nil
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jvmOptions make the JVM size its heap from the container memory limit
+// (container support is on by default since Java 10; the default heap is only 25%)
+const jvmOptions = "-XX:MaxRAMPercentage=75.0 -XX:InitialRAMPercentage=50.0 -XX:+ExitOnOutOfMemoryError"
+
+func (g *Generator) generateJavaDockerfile() (string, error) {
+	var sb strings.Builder
+
+	javaVersion := g.plan.LanguageVersion
+	if javaVersion == "" {
+		javaVersion = "21"
+	}
+
+	wrapper := g.plan.Java.Wrapper
+	artifact := g.plan.Java.Artifact
+
+	// Determine builder image (COOLPACK_BASE_IMAGE overrides default)
+	// With a wrapper only a JDK is needed, the wrapper downloads the build tool
+	var baseImage string
+	switch {
+	case wrapper != "":
+		baseImage = fmt.Sprintf("eclipse-temurin:%s-jdk", javaVersion)
+	case g.plan.PackageManager == "gradle":
+		baseImage = fmt.Sprintf("gradle:jdk%s", javaVersion)
+	default:
+		baseImage = fmt.Sprintf("maven:3-eclipse-temurin-%s", javaVersion)
+	}
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Build: %s, Output: server\n\n", g.plan.Provider, g.plan.Framework, g.plan.PackageManager))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Keep the Gradle home in the cache mount regardless of the image's user
+	if g.plan.PackageManager == "gradle" {
+		sb.WriteString("ENV GRADLE_USER_HOME=/root/.gradle\n\n")
+	}
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	if wrapper != "" {
+		sb.WriteString(fmt.Sprintf("RUN chmod +x %s\n\n", wrapper))
+	}
+
+	// Build with the dependency cache mounted, then move the artifact to a fixed path
+	cacheDir := "/root/.m2"
+	if g.plan.PackageManager == "gradle" {
+		cacheDir = "/root/.gradle"
+	}
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=%s \\\n", cacheDir))
+		sb.WriteString(fmt.Sprintf("    %s && \\\n", g.plan.BuildCommand))
+		if strings.HasSuffix(artifact, ".jar") {
+			// Skip the plain, sources and javadoc jars next to the runnable one
+			sb.WriteString(fmt.Sprintf("    cp \"$(ls %s | grep -v -e '-plain.jar$' -e '-sources.jar$' -e '-javadoc.jar$' | head -n 1)\" /app/app.jar\n\n", artifact))
+		} else {
+			sb.WriteString(fmt.Sprintf("    cp -r %s /app/quarkus-app\n\n", artifact))
+		}
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM eclipse-temurin:%s-jre AS runner\n", javaVersion))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Runtime libraries
+	g.writeRuntimeAptInstall(&sb)
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	// JAVA_TOOL_OPTIONS is read by every JVM, including custom start commands
+	sb.WriteString(fmt.Sprintf("ENV JAVA_TOOL_OPTIONS=\"%s\" \\\n", jvmOptions))
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy only the runnable artifact
+	if strings.HasSuffix(artifact, ".jar") {
+		sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app/app.jar /app/app.jar\n\n")
+	} else {
+		sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app/quarkus-app /app/quarkus-app\n\n")
+	}
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"java\", \"-jar\", \"/app/app.jar\"]\n")
+	}
+
+	return sb.String(), nil
+}
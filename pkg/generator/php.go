@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+func (g *Generator) generatePHPDockerfile() (string, error) {
+	var sb strings.Builder
+
+	phpVersion := g.plan.LanguageVersion
+	if phpVersion == "" {
+		phpVersion = "8.4"
+	}
+
+	runner := g.plan.Runtime.Runner
+	if runner == "" {
+		runner = "frankenphp"
+	}
+
+	// Determine base image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("dunglas/frankenphp:1-php%s", phpVersion)
+	if runner == "fpm" {
+		baseImage = fmt.Sprintf("php:%s-fpm", phpVersion)
+	}
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Runner: %s, Output: server\n\n", g.plan.Provider, g.plan.Framework, runner))
+
+	// Base stage shared by builder and runner, so Composer's platform checks
+	// see the same extensions the application runs with
+	sb.WriteString(fmt.Sprintf("FROM %s AS base\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	g.writePHPExtensions(&sb)
+
+	if runner == "fpm" {
+		sb.WriteString("COPY --from=caddy:2 /usr/bin/caddy /usr/bin/caddy\n\n")
+	}
+
+	sb.WriteString("RUN cp \"$PHP_INI_DIR/php.ini-production\" \"$PHP_INI_DIR/php.ini\"\n\n")
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	g.writePHPFrameworkEnv(&sb)
+
+	// Build stage
+	sb.WriteString("FROM base AS builder\n\n")
+
+	sb.WriteString("ENV COMPOSER_ALLOW_SUPERUSER=1 \\\n")
+	sb.WriteString("    COMPOSER_CACHE_DIR=/tmp/composer-cache\n\n")
+
+	// Install APT packages (git, unzip for Composer)
+	g.writeAptInstall(&sb)
+
+	// Node.js for Vite/Encore asset builds
+	g.writeNodeToolchain(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	sb.WriteString("COPY --from=composer:2 /usr/bin/composer /usr/bin/composer\n\n")
+
+	// Copy composer files first (for better caching), then install
+	sb.WriteString("COPY composer.json composer.lock* ./\n\n")
+	sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=/tmp/composer-cache %s\n\n", g.plan.InstallCommand))
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Build (autoloader, framework scripts, frontend assets)
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s\n\n", g.plan.BuildCommand))
+	}
+
+	// Production stage
+	sb.WriteString("FROM base AS runner\n\n")
+
+	if runner == "fpm" {
+		g.writePHPFPMConfig(&sb)
+	} else {
+		// FrankenPHP keeps Caddy's config and data under /config and /data
+		sb.WriteString("RUN chown -R cooluser:coolgroup /config/caddy /data/caddy\n\n")
+	}
+
+	sb.WriteString("ENV PORT=3000\n\n")
+
+	// Copy application with vendor/ and built assets
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app /app\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"sh\", \"-c\", \"exec frankenphp php-server --listen :$PORT\"]\n")
+	}
+
+	return sb.String(), nil
+}
+
+// writePHPExtensions installs the PHP extensions listed in the plan
+// install-php-extensions resolves and cleans up the system libraries each extension needs
+func (g *Generator) writePHPExtensions(sb *strings.Builder) {
+	extensions := g.plan.PHP.Extensions
+	if len(extensions) == 0 {
+		return
+	}
+
+	sb.WriteString("COPY --from=mlocati/php-extension-installer:2 /usr/bin/install-php-extensions /usr/local/bin/\n")
+	sb.WriteString(fmt.Sprintf("RUN install-php-extensions %s\n\n", strings.Join(extensions, " ")))
+}
+
+// writePHPFrameworkEnv writes framework environment settings shared by builder and runner
+func (g *Generator) writePHPFrameworkEnv(sb *strings.Builder) {
+	switch g.plan.Framework {
+	case "laravel":
+		sb.WriteString("ENV LOG_CHANNEL=stderr\n\n")
+	case "symfony":
+		// Dev bundles aren't installed (--no-dev), so the kernel must boot in prod
+		sb.WriteString("ENV APP_ENV=prod\n\n")
+	}
+}
+
+// writePHPFPMConfig writes the Caddyfile that serves the document root and
+// forwards PHP requests to php-fpm
+func (g *Generator) writePHPFPMConfig(sb *strings.Builder) {
+	documentRoot := g.plan.PHP.DocumentRoot
+	root := path.Join("/app", documentRoot)
+
+	sb.WriteString("# Caddy serves static files and forwards PHP to php-fpm\n")
+	sb.WriteString("RUN mkdir -p /etc/caddy && \\\n")
+	sb.WriteString(fmt.Sprintf("    printf '%%s\\n' '{' '    admin off' '    auto_https off' '}' ':{$PORT} {' '    root * %s' '    encode' '    php_fastcgi 127.0.0.1:9000' '    file_server' '}' > /etc/caddy/Caddyfile\n\n", root))
+
+	// php-fpm clears the environment by default; runtime config comes from env vars
+	sb.WriteString("RUN echo 'clear_env = no' >> /usr/local/etc/php-fpm.d/zz-docker.conf\n\n")
+
+	sb.WriteString("ENV XDG_CONFIG_HOME=/tmp \\\n")
+	sb.WriteString("    XDG_DATA_HOME=/tmp\n\n")
+}
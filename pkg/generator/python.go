@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+func (g *Generator) generatePythonDockerfile() (string, error) {
+	var sb strings.Builder
+
+	pythonVersion := g.plan.LanguageVersion
+	if pythonVersion == "" {
+		pythonVersion = "3.13"
+	}
+
+	outputType := "server"
+	if ot := g.plan.Runtime.OutputType; ot != "" {
+		outputType = ot
+	}
+
+	// Determine base image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("python:%s-slim", pythonVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	pm := g.plan.PackageManager
+	if pm == "" {
+		pm = "pip"
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, outputType))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	sb.WriteString("ENV PYTHONDONTWRITEBYTECODE=1 \\\n")
+	sb.WriteString("    PYTHONUNBUFFERED=1 \\\n")
+	sb.WriteString("    PIP_DISABLE_PIP_VERSION_CHECK=1\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Install package manager if not pip
+	g.writePythonPackageManagerInstall(&sb, pm)
+
+	// Create the virtual environment dependencies are installed into
+	sb.WriteString("RUN python -m venv /app/.venv\n")
+	sb.WriteString("ENV VIRTUAL_ENV=/app/.venv \\\n")
+	sb.WriteString("    PATH=\"/app/.venv/bin:$PATH\"\n\n")
+
+	// Copy dependency files first (for better caching), then install
+	cacheMount := g.getPythonCacheMount(pm)
+	// Application servers the start command needs (gunicorn, uvicorn) are installed right after
+	if g.writeCopyPythonDependencyFiles(&sb) {
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		g.writePythonServerPackages(&sb, pm, cacheMount)
+		sb.WriteString("COPY . .\n\n")
+		if cmd := g.plan.Python.ProjectInstallCommand; cmd != "" {
+			sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, cmd))
+		}
+	} else {
+		// Install needs the full source tree (e.g., pip install .)
+		sb.WriteString("COPY . .\n\n")
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		g.writePythonServerPackages(&sb, pm, cacheMount)
+	}
+
+	// Build if there's a build command
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s\n\n", g.plan.BuildCommand))
+	}
+
+	// Documentation generators (MkDocs) only ship the generated site
+	if outputType == "static" {
+		g.writeStaticServerStage(&sb, builderOutput(g.getStaticOutputDir()))
+		return sb.String(), nil
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS runner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	// Runtime environment (build envs are NOT included - pass at runtime via docker run -e)
+	sb.WriteString("ENV PYTHONDONTWRITEBYTECODE=1 \\\n")
+	sb.WriteString("    PYTHONUNBUFFERED=1 \\\n")
+	sb.WriteString("    VIRTUAL_ENV=/app/.venv \\\n")
+	sb.WriteString("    PATH=\"/app/.venv/bin:$PATH\" \\\n")
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy application and virtual environment
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app /app\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"python\", \"main.py\"]\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (g *Generator) writePythonPackageManagerInstall(sb *strings.Builder, pm string) {
+	version := g.plan.PackageManagerVersion
+
+	switch pm {
+	case "poetry":
+		pkg := "poetry"
+		if version != "" {
+			pkg = "poetry==" + version
+		}
+		sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=/root/.cache/pip pip install %s\n", pkg))
+		sb.WriteString("ENV POETRY_VIRTUALENVS_CREATE=false\n\n")
+	case "uv":
+		if version == "" {
+			version = "latest"
+		}
+		sb.WriteString(fmt.Sprintf("COPY --from=ghcr.io/astral-sh/uv:%s /uv /uvx /bin/\n", version))
+		sb.WriteString("ENV UV_PROJECT_ENVIRONMENT=/app/.venv \\\n")
+		sb.WriteString("    UV_COMPILE_BYTECODE=1 \\\n")
+		sb.WriteString("    UV_LINK_MODE=copy \\\n")
+		sb.WriteString("    UV_PYTHON_DOWNLOADS=never\n\n")
+	case "pipenv":
+		pkg := "pipenv"
+		if version != "" {
+			pkg = "pipenv==" + version
+		}
+		sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=/root/.cache/pip pip install %s\n\n", pkg))
+	}
+}
+
+// writePythonServerPackages installs application server packages that the
+// start command relies on but the project doesn't declare
+func (g *Generator) writePythonServerPackages(sb *strings.Builder, pm string, cacheMount string) {
+	packages := g.plan.Python.ServerPackages
+	if len(packages) == 0 {
+		return
+	}
+
+	// uv environments don't include pip
+	installer := "pip install"
+	if pm == "uv" {
+		installer = "uv pip install"
+	}
+
+	sb.WriteString(fmt.Sprintf("RUN %s%s %s\n\n", cacheMount, installer, strings.Join(packages, " ")))
+}
+
+// writeCopyPythonDependencyFiles copies the dependency files listed in the plan
+// Returns false if there are none and the install step needs the full source
+func (g *Generator) writeCopyPythonDependencyFiles(sb *strings.Builder) bool {
+	files := g.plan.Python.DependencyFiles
+	if len(files) == 0 {
+		return false
+	}
+
+	// Group files by directory so nested requirements keep their paths
+	var rootFiles []string
+	for _, f := range files {
+		dir := path.Dir(f)
+		if dir == "." {
+			rootFiles = append(rootFiles, f)
+		} else {
+			sb.WriteString(fmt.Sprintf("COPY %s ./%s/\n", f, dir))
+		}
+	}
+	if len(rootFiles) > 0 {
+		sb.WriteString(fmt.Sprintf("COPY %s ./\n", strings.Join(rootFiles, " ")))
+	}
+	sb.WriteString("\n")
+
+	return true
+}
+
+// getPythonCacheMount returns the BuildKit cache mount for the package manager (install phase)
+func (g *Generator) getPythonCacheMount(pm string) string {
+	switch pm {
+	case "uv":
+		return "--mount=type=cache,target=/root/.cache/uv "
+	case "poetry":
+		return "--mount=type=cache,target=/root/.cache/pypoetry --mount=type=cache,target=/root/.cache/pip "
+	case "pipenv":
+		return "--mount=type=cache,target=/root/.cache/pipenv --mount=type=cache,target=/root/.cache/pip "
+	default:
+		return "--mount=type=cache,target=/root/.cache/pip "
+	}
+}
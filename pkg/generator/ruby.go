@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateRubyDockerfile() (string, error) {
+	var sb strings.Builder
+
+	rubyVersion := g.plan.LanguageVersion
+	if rubyVersion == "" {
+		rubyVersion = "3.4"
+	}
+
+	outputType := "server"
+	if ot := g.plan.Runtime.OutputType; ot != "" {
+		outputType = ot
+	}
+
+	// Determine base image (COOLPACK_BASE_IMAGE overrides default)
+	baseImage := fmt.Sprintf("ruby:%s-slim", rubyVersion)
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	}
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Framework: %s, Output: %s\n\n", g.plan.Provider, g.plan.Framework, outputType))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	g.writeRubyEnv(&sb)
+	sb.WriteString("ENV BUNDLE_GLOBAL_GEM_CACHE=1\n\n")
+
+	// Install APT packages for compiling native gems
+	g.writeAptInstall(&sb)
+
+	// Node.js for jsbundling/cssbundling/vite asset builds
+	g.writeNodeToolchain(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Install the Bundler version that wrote Gemfile.lock
+	if version := g.plan.PackageManagerVersion; version != "" {
+		sb.WriteString(fmt.Sprintf("RUN gem install bundler:%s --no-document\n\n", version))
+	}
+
+	// Copy Gemfile first (for better caching), then install with the gem download cache mounted
+	sb.WriteString("COPY Gemfile Gemfile.lock* ./\n\n")
+	sb.WriteString(fmt.Sprintf("RUN --mount=type=cache,target=/root/.bundle/cache %s && \\\n", g.plan.InstallCommand))
+	sb.WriteString("    rm -rf /usr/local/bundle/ruby/*/cache\n\n")
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Build if there's a build command (e.g., assets:precompile)
+	if g.plan.BuildCommand != "" {
+		sb.WriteString(fmt.Sprintf("RUN %s\n\n", g.plan.BuildCommand))
+	}
+
+	// Static site generators (Jekyll) only ship the generated site
+	if outputType == "static" {
+		g.writeStaticServerStage(&sb, builderOutput(g.getStaticOutputDir()))
+		return sb.String(), nil
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS runner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Runtime libraries of native gems
+	g.writeRuntimeAptInstall(&sb)
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	// Runtime environment (build envs are NOT included - pass at runtime via docker run -e)
+	g.writeRubyEnv(&sb)
+	sb.WriteString("ENV RAILS_LOG_TO_STDOUT=1 \\\n")
+	sb.WriteString("    RAILS_SERVE_STATIC_FILES=1 \\\n")
+	sb.WriteString("    PORT=3000\n\n")
+
+	// Copy installed gems and application
+	sb.WriteString("COPY --from=builder /usr/local/bundle /usr/local/bundle\n")
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app /app\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"bundle\", \"exec\", \"rackup\", \"--host\", \"0.0.0.0\"]\n")
+	}
+
+	return sb.String(), nil
+}
+
+// writeRubyEnv writes the Bundler and environment settings shared by both stages
+func (g *Generator) writeRubyEnv(sb *strings.Builder) {
+	sb.WriteString("ENV RAILS_ENV=production \\\n")
+	sb.WriteString("    RACK_ENV=production \\\n")
+	sb.WriteString("    HANAMI_ENV=production \\\n")
+	sb.WriteString("    BUNDLE_PATH=/usr/local/bundle \\\n")
+	sb.WriteString("    BUNDLE_WITHOUT=\"development:test\"\n")
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *Generator) generateRustDockerfile() (string, error) {
+	var sb strings.Builder
+
+	rustVersion := g.plan.LanguageVersion
+	if rustVersion == "" {
+		rustVersion = "1"
+	}
+
+	// Determine builder image (COOLPACK_BASE_IMAGE overrides default)
+	// The builder and runner share the same Debian release so the binary's glibc matches
+	var baseImage string
+	if customBase := g.plan.Runtime.BaseImage; customBase != "" {
+		baseImage = customBase
+	} else if rustVersion == "nightly" {
+		baseImage = "rustlang/rust:nightly-bookworm-slim"
+	} else {
+		baseImage = fmt.Sprintf("rust:%s-slim-bookworm", rustVersion)
+	}
+
+	binary := g.plan.Rust.Binary
+
+	// Write Dockerfile with BuildKit syntax for cache mounts
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Binary: %s, Output: server\n\n", g.plan.Provider, binary))
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(&sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(&sb)
+
+	// Copy source code
+	sb.WriteString("COPY . .\n\n")
+
+	// Build with the cargo registry and target/ in cache mounts, so dependencies are
+	// only recompiled when Cargo.lock changes. target/ isn't part of the image layer,
+	// so the release binary is copied out in the same step
+	if g.plan.BuildCommand != "" {
+		sb.WriteString("RUN --mount=type=cache,target=/usr/local/cargo/registry \\\n")
+		sb.WriteString("    --mount=type=cache,target=/usr/local/cargo/git \\\n")
+		sb.WriteString("    --mount=type=cache,target=/app/target \\\n")
+		sb.WriteString(fmt.Sprintf("    %s && \\\n", g.plan.BuildCommand))
+		sb.WriteString(fmt.Sprintf("    cp target/release/%s /app/server\n\n", binary))
+	}
+
+	// Production stage
+	sb.WriteString("FROM debian:bookworm-slim AS runner\n")
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Runtime libraries (TLS roots plus libraries of linked -sys crates)
+	g.writeRuntimeAptInstall(&sb, "ca-certificates")
+
+	// Create non-root user
+	g.writeCreateUser(&sb)
+
+	sb.WriteString("ENV PORT=3000\n\n")
+
+	// Copy only the release binary
+	sb.WriteString("COPY --from=builder --chown=cooluser:coolgroup /app/server /app/server\n\n")
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(&sb)
+
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"/app/server\"]\n")
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// generateStaticSiteDockerfile serves files straight from the build context
+// A site in the project root is copied through a builder stage that drops dotfiles
+// (.git, .env, but not .well-known) and Coolpack's files, so they aren't published with it
+func (g *Generator) generateStaticSiteDockerfile() (string, error) {
+	var sb strings.Builder
+
+	siteDir := "."
+	if dir := g.plan.Static.SiteDir; dir != "" {
+		siteDir = dir
+	}
+	if override := g.plan.Static.OutputDir; override != "" {
+		siteDir = override
+	}
+
+	sb.WriteString("# syntax=docker/dockerfile:1\n")
+	sb.WriteString("# Generated by Coolpack\n")
+	sb.WriteString(fmt.Sprintf("# Provider: %s, Output: static\n\n", g.plan.Provider))
+
+	if path.Clean(siteDir) == "." {
+		sb.WriteString("FROM alpine AS builder\n")
+		sb.WriteString("WORKDIR /site\n\n")
+		sb.WriteString("COPY . .\n\n")
+		sb.WriteString("RUN find . -mindepth 1 -name '.*' ! -name .well-known -prune -exec rm -rf {} + && \\\n")
+		sb.WriteString("    rm -f coolpack.json coolpack.toml coolpack.native.json\n\n")
+		siteDir = "--from=builder /site"
+	}
+
+	g.writeStaticServerStage(&sb, siteDir)
+
+	return sb.String(), nil
+}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// writeWorkspaceDockerfile writes the stages for one app of a monorepo
+// Turborepo and Moon prune the workspace in a separate stage, other workspaces copy every
+// manifest and install with the package manager's filter. pnpm apps are deployed with
+// their production dependencies only
+func (g *Generator) writeWorkspaceDockerfile(sb *strings.Builder, baseImage string, outputType string) {
+	pm := g.plan.PackageManager
+	if pm == "" {
+		pm = "npm"
+	}
+	tool := g.plan.Workspace.Tool
+	workdir := g.plan.Workspace.Workdir
+
+	// Prune stage
+	switch tool {
+	case "turbo":
+		g.writeTurboPruneStage(sb, baseImage, pm)
+	case "moon":
+		g.writeMoonScaffoldStage(sb, baseImage, pm)
+	}
+
+	// Build stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS builder\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Install APT packages for native dependencies
+	g.writeAptInstall(sb)
+
+	// Declare build-time ARGs
+	g.writeBuildArgs(sb)
+
+	// Install package manager if not npm
+	g.writePackageManagerInstall(sb, pm)
+
+	// Install the dependencies of the app's graph, then copy its sources
+	cacheMount := g.getCacheMount(pm)
+	switch tool {
+	case "turbo":
+		sb.WriteString(fmt.Sprintf("COPY --from=pruner /app/out/json/ /app/out/%s* ./\n", workspaceLockFile(pm)))
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		sb.WriteString("COPY --from=pruner /app/out/full/ .\n\n")
+	case "moon":
+		sb.WriteString(fmt.Sprintf("RUN %s @moonrepo/cli\n", globalInstallCommand(pm)))
+		sb.WriteString("COPY --from=pruner /app/.moon/docker/workspace .\n")
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		sb.WriteString("COPY --from=pruner /app/.moon/docker/sources .\n\n")
+	default:
+		g.writeCopyWorkspaceManifests(sb, pm)
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", cacheMount, g.plan.InstallCommand))
+		sb.WriteString("COPY . .\n\n")
+	}
+
+	// Build the app and the workspace packages it depends on
+	if g.plan.BuildCommand != "" {
+		buildCacheMount := g.getBuildCacheMount()
+		sb.WriteString(fmt.Sprintf("RUN %s%s\n\n", buildCacheMount, g.plan.BuildCommand))
+	}
+
+	if outputType == "static" {
+		g.writeStaticServerStage(sb, builderOutput(path.Join(workdir, g.getStaticOutputDir())))
+		return
+	}
+
+	// Drop development dependencies
+	deploy := pm == "pnpm" && tool != "turbo" && tool != "moon" && workdir != ""
+	switch {
+	case tool == "moon":
+		sb.WriteString("RUN moon docker prune\n\n")
+	case deploy:
+		// pnpm deploy only copies the files the package would publish, so the build
+		// output (usually git-ignored) is copied over afterwards
+		appName := g.plan.Workspace.AppName
+		sb.WriteString(fmt.Sprintf("RUN pnpm --filter %s --prod --config.force-legacy-deploy=true deploy /prod/app && \\\n", appName))
+		sb.WriteString(fmt.Sprintf("    tar -C %s --exclude=./node_modules -cf - . | tar -C /prod/app -xf -\n\n", workdir))
+	}
+
+	// Production stage
+	sb.WriteString(fmt.Sprintf("FROM %s AS runner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+
+	// Install the libraries native dependencies load at runtime
+	g.writeRuntimeAptInstall(sb)
+
+	// Install package manager if not npm
+	g.writePackageManagerInstall(sb, pm)
+
+	// Create non-root user
+	g.writeCreateUser(sb)
+
+	// Set production environment (build envs are NOT included - pass at runtime via docker run -e)
+	sb.WriteString("ENV NODE_ENV=production\n\n")
+
+	// Copy the deployed app, or the pruned workspace and start from the app directory
+	if deploy {
+		sb.WriteString("COPY --from=builder /prod/app ./\n\n")
+	} else {
+		sb.WriteString("COPY --from=builder /app ./\n\n")
+		if workdir != "" {
+			sb.WriteString(fmt.Sprintf("WORKDIR /app/%s\n\n", workdir))
+		}
+	}
+
+	// Launcher for Procfile process types
+	g.writeProcessLauncher(sb)
+
+	// Set ownership and switch to non-root user
+	sb.WriteString("RUN chown -R cooluser:coolgroup /app\n")
+	sb.WriteString("USER cooluser\n\n")
+
+	// Expose port
+	sb.WriteString("EXPOSE 3000\n\n")
+
+	// Start command
+	if g.plan.StartCommand != "" {
+		sb.WriteString(fmt.Sprintf("CMD %s\n", g.formatCmdCommand(g.plan.StartCommand)))
+	} else {
+		sb.WriteString("CMD [\"node\", \"index.js\"]\n")
+	}
+}
+
+// writeTurboPruneStage writes the stage reducing the workspace to the app's graph
+// turbo prune --docker splits the output into manifests (out/json) and sources (out/full)
+func (g *Generator) writeTurboPruneStage(sb *strings.Builder, baseImage string, pm string) {
+	appName := g.plan.Workspace.AppName
+	version := g.plan.Workspace.TurboVersion
+	if version == "" {
+		version = "latest"
+	}
+
+	sb.WriteString(fmt.Sprintf("FROM %s AS pruner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+	sb.WriteString(fmt.Sprintf("RUN %s turbo@%s\n", globalInstallCommand(pm), version))
+	sb.WriteString("COPY . .\n")
+	sb.WriteString(fmt.Sprintf("RUN turbo prune %s --docker\n\n", appName))
+}
+
+// writeMoonScaffoldStage writes the stage reducing the workspace to the project's graph
+// moon docker scaffold splits the output into manifests (workspace) and sources
+func (g *Generator) writeMoonScaffoldStage(sb *strings.Builder, baseImage string, pm string) {
+	project := g.plan.Workspace.AppProject
+	if project == "" {
+		project = g.plan.Workspace.AppName
+	}
+
+	sb.WriteString(fmt.Sprintf("FROM %s AS pruner\n", baseImage))
+	sb.WriteString("WORKDIR /app\n\n")
+	sb.WriteString(fmt.Sprintf("RUN %s @moonrepo/cli\n", globalInstallCommand(pm)))
+	sb.WriteString("COPY . .\n")
+	sb.WriteString(fmt.Sprintf("RUN moon docker scaffold %s\n\n", project))
+}
+
+// writeCopyWorkspaceManifests copies the root package files and every workspace
+// package.json, so the install layer is cached until a manifest changes
+func (g *Generator) writeCopyWorkspaceManifests(sb *strings.Builder, pm string) {
+	sb.WriteString(fmt.Sprintf("COPY package.json %s* ", workspaceLockFile(pm)))
+	switch pm {
+	case "pnpm":
+		sb.WriteString("pnpm-workspace.yaml* ")
+	case "yarn", "yarnberry":
+		sb.WriteString(".yarnrc.yml* ")
+	case "bun":
+		sb.WriteString("bun.lockb* ")
+	}
+	sb.WriteString("./\n")
+
+	if manifests := g.plan.Workspace.Manifests; len(manifests) > 0 {
+		for _, manifest := range manifests {
+			sb.WriteString(fmt.Sprintf("COPY %s %s/\n", manifest, path.Dir(manifest)))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// workspaceLockFile returns the lock file of the package manager
+func workspaceLockFile(pm string) string {
+	switch pm {
+	case "pnpm":
+		return "pnpm-lock.yaml"
+	case "yarn", "yarnberry":
+		return "yarn.lock"
+	case "bun":
+		return "bun.lock"
+	default:
+		return "package-lock.json"
+	}
+}
+
+// globalInstallCommand returns the command installing a CLI globally
+// The oven/bun image has no npm
+func globalInstallCommand(pm string) string {
+	if pm == "bun" {
+		return "bun add -g"
+	}
+	return "npm install -g"
+}
@@ -0,0 +1,135 @@
+package deno
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Config represents the parts of deno.json(c) that Coolpack cares about
+type Config struct {
+	// File is the config file that was read (deno.json or deno.jsonc)
+	File string `json:"-"`
+
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Tasks   map[string]Task   `json:"tasks"`
+	Imports map[string]string `json:"imports"`
+}
+
+// Task is a deno.json task, either a command string or an object with a command
+type Task struct {
+	Command string
+}
+
+// UnmarshalJSON handles both "build": "deno run ..." and "build": {"command": "..."}
+func (t *Task) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Command); err == nil {
+		return nil
+	}
+	var obj struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	t.Command = obj.Command
+	return nil
+}
+
+// configFiles are the Deno config files, in priority order
+var configFiles = []string{"deno.json", "deno.jsonc"}
+
+// LoadConfig reads deno.json or deno.jsonc
+// Returns an empty config if the project only has deno.lock
+func LoadConfig(ctx *app.Context) (*Config, error) {
+	for _, file := range configFiles {
+		data, err := ctx.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var config Config
+		if err := json.Unmarshal(StripJSONC(data), &config); err != nil {
+			return nil, err
+		}
+		config.File = file
+		return &config, nil
+	}
+	return &Config{}, nil
+}
+
+// HasTask checks if a task is defined
+func (c *Config) HasTask(name string) bool {
+	_, ok := c.Tasks[name]
+	return ok
+}
+
+// GetTask returns the command of a task
+func (c *Config) GetTask(name string) string {
+	return c.Tasks[name].Command
+}
+
+// Import returns the specifier mapped to an import prefix, matching keys with or without a trailing slash
+func (c *Config) Import(prefix string) string {
+	for key, value := range c.Imports {
+		if strings.TrimSuffix(key, "/") == prefix {
+			return value
+		}
+	}
+	return ""
+}
+
+// StripJSONC removes comments and trailing commas so JSONC can be parsed as JSON
+func StripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// Drop a trailing comma before the closing bracket
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
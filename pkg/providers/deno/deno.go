@@ -0,0 +1,147 @@
+package deno
+
+import (
+	"fmt"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Provider is the Deno provider implementation
+type Provider struct{}
+
+// New creates a new Deno provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "deno"
+}
+
+// detectFiles are the files that identify a Deno project
+var detectFiles = []string{"deno.json", "deno.jsonc", "deno.lock"}
+
+// Detect checks if the application is a Deno project
+// A Deno config outranks package.json, which Deno 2 projects may also have
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	for _, f := range []string{"deno.json", "deno.jsonc"} {
+		if ctx.HasFile(f) {
+			return app.Detected(app.ConfidenceSpecific, "found "+f), nil
+		}
+	}
+	if ctx.HasFile("deno.lock") {
+		return app.Detected(app.ConfidenceApp, "found deno.lock"), nil
+	}
+	return app.NoMatch(), nil
+}
+
+// Plan generates a build plan for the Deno application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	config, err := LoadConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Deno config: %w", err)
+	}
+
+	// Detect Deno version
+	denoVersion := DetectDenoVersion(ctx)
+
+	// Detect framework
+	fwInfo := DetectFramework(config)
+
+	plan := &app.Plan{
+		Provider:        "deno",
+		Language:        "deno",
+		LanguageVersion: denoVersion,
+		PackageManager:  "deno",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	// Add framework info
+	if fwInfo.Name != FrameworkNone {
+		plan.Framework = string(fwInfo.Name)
+		plan.FrameworkVersion = fwInfo.Version
+	}
+
+	// Install dependencies from the config and lock file (Deno 2)
+	if ctx.HasFile("deno.lock") {
+		plan.InstallCommand = "deno install --frozen"
+	} else {
+		plan.InstallCommand = "deno install"
+	}
+
+	plan.BuildCommand = determineBuildCommand(config, fwInfo)
+
+	plan.Runtime.OutputType = fwInfo.OutputType
+	if fwInfo.OutputType == "static" {
+		plan.Static.OutputDir = fwInfo.GetDefaultOutputDir()
+		plan.SetSource("static.output_dir", fmt.Sprintf("%s default", fwInfo.Name))
+	} else {
+		startCommand, entrypoint := determineStartCommand(ctx, config, fwInfo)
+		plan.StartCommand = startCommand
+		if entrypoint != "" {
+			plan.Deno.Entrypoint = entrypoint
+		}
+	}
+
+	if config.Name != "" {
+		plan.Project.Name = config.Name
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// determineBuildCommand determines the build command to use
+func determineBuildCommand(config *Config, fw FrameworkInfo) string {
+	// Check for explicit build task
+	if config.HasTask("build") {
+		return "deno task build"
+	}
+
+	// Use framework-specific defaults
+	return fw.GetDefaultBuildCommand()
+}
+
+// determineStartCommand determines the start command to use, and the entry point
+// to pre-cache when the command runs a module directly
+func determineStartCommand(ctx *app.Context, config *Config, fw FrameworkInfo) (string, string) {
+	// Use framework-specific defaults (Fresh's start task runs the dev server)
+	if cmd := fw.GetDefaultStartCommand(); cmd != "" {
+		return cmd, fw.GetEntrypoint()
+	}
+
+	// Check for explicit start task
+	if config.HasTask("start") {
+		return "deno task start", ""
+	}
+
+	// Check for explicit serve task
+	if config.HasTask("serve") {
+		return "deno task serve", ""
+	}
+
+	// Check for common entry points
+	for _, ep := range []string{"main.ts", "main.tsx", "main.js", "server.ts", "server.js", "mod.ts", "index.ts", "index.js"} {
+		if ctx.HasFile(ep) {
+			return fmt.Sprintf("deno run -A %s", ep), ep
+		}
+	}
+
+	return "", ""
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range append(append([]string{}, detectFiles...), "package.json", "_config.ts", "fresh.gen.ts", ".dvmrc", ".tool-versions") {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -0,0 +1,115 @@
+package deno
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Framework represents a detected Deno framework
+type Framework string
+
+const (
+	FrameworkNone  Framework = ""
+	FrameworkFresh Framework = "fresh"
+	FrameworkLume  Framework = "lume"
+)
+
+// FrameworkInfo contains information about the detected framework
+type FrameworkInfo struct {
+	Name    Framework
+	Version string
+
+	// OutputType is "server" or "static"
+	OutputType string
+}
+
+// specifierVersionRegex extracts the version from an import specifier
+// "https://deno.land/x/fresh@1.6.8/" -> "1.6.8", "jsr:@fresh/core@^2.0.0" -> "2.0.0"
+var specifierVersionRegex = regexp.MustCompile(`@[\^~]?v?(\d+(?:\.\d+)*)`)
+
+// DetectFramework detects the framework used by the project from its import map
+func DetectFramework(config *Config) FrameworkInfo {
+	info := FrameworkInfo{
+		Name:       FrameworkNone,
+		OutputType: "server",
+	}
+
+	for key, specifier := range config.Imports {
+		switch {
+		case strings.Contains(specifier, "/x/fresh@") || strings.Contains(specifier, "@fresh/core"):
+			info.Name = FrameworkFresh
+			info.Version = specifierVersion(specifier)
+			return info
+		case strings.Contains(specifier, "/x/lume@") || strings.Contains(specifier, "@lume/lume") || strings.TrimSuffix(key, "/") == "lume":
+			info.Name = FrameworkLume
+			info.Version = specifierVersion(specifier)
+			info.OutputType = "static"
+			return info
+		}
+	}
+
+	return info
+}
+
+// specifierVersion returns the version pinned in an import specifier
+func specifierVersion(specifier string) string {
+	matches := specifierVersionRegex.FindStringSubmatch(specifier)
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
+
+// isFresh2 reports whether the Fresh version is 2.x (built into _fresh/)
+func (f FrameworkInfo) isFresh2() bool {
+	return f.Name == FrameworkFresh && strings.HasPrefix(f.Version, "2")
+}
+
+// GetDefaultBuildCommand returns the default build command for a framework
+func (f FrameworkInfo) GetDefaultBuildCommand() string {
+	switch f.Name {
+	case FrameworkFresh:
+		if !f.isFresh2() {
+			// Fresh 1.x pre-builds islands ahead of time with dev.ts build
+			return "deno run -A dev.ts build"
+		}
+	case FrameworkLume:
+		// Same as the lume task Lume's init script adds
+		return `echo "import 'lume/cli.ts'" | deno run -A -`
+	}
+	return ""
+}
+
+// GetDefaultStartCommand returns the default start command for a framework
+// Takes precedence over the start task, which Fresh projects use for the dev server
+func (f FrameworkInfo) GetDefaultStartCommand() string {
+	switch f.Name {
+	case FrameworkFresh:
+		if f.isFresh2() {
+			return "deno serve -A --port $PORT _fresh/server.js"
+		}
+		// Fresh 1.x reads PORT from the environment
+		return "deno run -A main.ts"
+	}
+	return ""
+}
+
+// GetDefaultOutputDir returns the directory a static site generator writes to
+func (f FrameworkInfo) GetDefaultOutputDir() string {
+	if f.Name == FrameworkLume {
+		return "_site"
+	}
+	return ""
+}
+
+// GetEntrypoint returns the module the default start command runs
+func (f FrameworkInfo) GetEntrypoint() string {
+	switch f.Name {
+	case FrameworkFresh:
+		if f.isFresh2() {
+			return "_fresh/server.js"
+		}
+		return "main.ts"
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package deno
+
+import (
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// DefaultDenoVersion is used when no version is pinned (like Bun, the image tag is "latest")
+const DefaultDenoVersion = "latest"
+
+// DetectDenoVersion detects the Deno version to use
+// Priority:
+// 1. COOLPACK_DENO_VERSION environment variable
+// 2. .dvmrc file
+// 3. .tool-versions file (asdf)
+// 4. Default to latest
+func DetectDenoVersion(ctx *app.Context) string {
+	// 1. Check COOLPACK_DENO_VERSION env var
+	if v := ctx.Env["COOLPACK_DENO_VERSION"]; v != "" {
+		return strings.TrimPrefix(strings.TrimSpace(v), "v")
+	}
+
+	// 2. Check .dvmrc file (dvm)
+	if ctx.HasFile(".dvmrc") {
+		if data, err := ctx.ReadFile(".dvmrc"); err == nil {
+			if v := strings.TrimPrefix(strings.TrimSpace(string(data)), "v"); v != "" {
+				return v
+			}
+		}
+	}
+
+	// 3. Check .tool-versions file (asdf format)
+	if v := ctx.ReadToolVersion("deno"); v != "" {
+		return v
+	}
+
+	// 4. Default
+	return DefaultDenoVersion
+}
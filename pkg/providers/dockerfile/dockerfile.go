@@ -0,0 +1,137 @@
+package dockerfile
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// DefaultFiles are the Dockerfile names looked for in the project root, in priority order
+var DefaultFiles = []string{"Dockerfile", "Containerfile"}
+
+var (
+	// fromRegex matches a FROM instruction and its image ("FROM node:20 AS build")
+	fromRegex = regexp.MustCompile(`(?i)^FROM\s+(?:--platform=\S+\s+)?(\S+)`)
+
+	// exposeRegex matches an EXPOSE instruction
+	exposeRegex = regexp.MustCompile(`(?i)^EXPOSE\s+(.+)`)
+)
+
+// Provider builds the project's own Dockerfile instead of generating one
+// It is opt-in: COOLPACK_USE_DOCKERFILE=true, or COOLPACK_DOCKERFILE=<path>
+type Provider struct{}
+
+// New creates a new Dockerfile provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "dockerfile"
+}
+
+// Detect checks if the project's Dockerfile should be used
+// An explicit COOLPACK_DOCKERFILE always matches, so a wrong path is reported by Plan
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if custom := ctx.Env["COOLPACK_DOCKERFILE"]; custom != "" {
+		return app.Detected(app.ConfidenceExplicit, "COOLPACK_DOCKERFILE is "+custom), nil
+	}
+	if !isTrue(ctx.Env["COOLPACK_USE_DOCKERFILE"]) {
+		return app.NoMatch(), nil
+	}
+	file := FindDockerfile(ctx)
+	if file == "" {
+		return app.NoMatch(), nil
+	}
+	return app.Detected(app.ConfidenceExplicit, "COOLPACK_USE_DOCKERFILE is set", "found "+file), nil
+}
+
+// Plan generates a build plan that passes the Dockerfile through to docker build
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	file := FindDockerfile(ctx)
+	if file == "" {
+		if custom := ctx.Env["COOLPACK_DOCKERFILE"]; custom != "" {
+			return nil, fmt.Errorf("dockerfile %s not found", custom)
+		}
+		return nil, fmt.Errorf("no Dockerfile or Containerfile found")
+	}
+
+	data, err := ctx.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	images, ports := parseDockerfile(string(data))
+
+	plan := &app.Plan{
+		Provider:      "dockerfile",
+		Language:      "dockerfile",
+		DetectedFiles: []string{file},
+	}
+
+	plan.Dockerfile.File = file
+	if len(images) > 0 {
+		plan.Dockerfile.Images = images
+	}
+	if len(ports) > 0 {
+		plan.Dockerfile.ExposedPorts = ports
+		plan.Runtime.Port = ports[0]
+	}
+
+	return plan, nil
+}
+
+// FindDockerfile returns the Dockerfile to build: COOLPACK_DOCKERFILE if set,
+// otherwise the first of DefaultFiles in the project root. Returns "" if there is none
+func FindDockerfile(ctx *app.Context) string {
+	if custom := ctx.Env["COOLPACK_DOCKERFILE"]; custom != "" {
+		if ctx.HasFile(custom) {
+			return custom
+		}
+		return ""
+	}
+	for _, f := range DefaultFiles {
+		if ctx.HasFile(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// parseDockerfile returns the images of the FROM instructions (skipping references
+// to earlier stages) and the ports of the EXPOSE instructions
+func parseDockerfile(content string) (images []string, ports []string) {
+	stages := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if matches := fromRegex.FindStringSubmatch(line); len(matches) > 1 {
+			if !stages[strings.ToLower(matches[1])] {
+				images = append(images, matches[1])
+			}
+			// Remember the stage name so later FROM <stage> lines are skipped
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && strings.EqualFold(fields[len(fields)-2], "AS") {
+				stages[strings.ToLower(fields[len(fields)-1])] = true
+			}
+			continue
+		}
+
+		if matches := exposeRegex.FindStringSubmatch(line); len(matches) > 1 {
+			for _, port := range strings.Fields(matches[1]) {
+				// 8080/tcp -> 8080
+				ports = append(ports, strings.SplitN(port, "/", 2)[0])
+			}
+		}
+	}
+	return images, ports
+}
+
+// isTrue reports whether an environment variable value enables a setting
+func isTrue(v string) bool {
+	return v == "true" || v == "1"
+}
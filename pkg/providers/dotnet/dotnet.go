@@ -0,0 +1,134 @@
+package dotnet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// PublishDir is where the published application is placed in the image
+const PublishDir = "/app/publish"
+
+// Provider is the .NET provider implementation
+type Provider struct{}
+
+// New creates a new .NET provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "dotnet"
+}
+
+// detectPatterns are the globs that identify a .NET project
+var detectPatterns = []string{"*.sln", "*.slnx", "*.csproj", "*.fsproj"}
+
+// Detect checks if the application is a .NET project
+// ASP.NET Core SPA templates ship a package.json, so web projects rank as an application
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasMatch(detectPatterns...) {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found a solution or project file")
+	for _, project := range DiscoverProjects(ctx) {
+		if project.IsWeb() && !project.IsTest() {
+			detection.Raise(app.ConfidenceApp, project.Path+" uses the ASP.NET Core SDK")
+			break
+		}
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the .NET application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	projects := DiscoverProjects(ctx)
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("no .csproj or .fsproj project found")
+	}
+
+	// Select the project to publish (COOLPACK_DOTNET_PROJECT > web project > executable)
+	project := SelectProject(projects)
+	if requested := ctx.Env["COOLPACK_DOTNET_PROJECT"]; requested != "" {
+		project = nil
+		var names []string
+		for _, p := range projects {
+			names = append(names, p.Path)
+			if p.Path == requested || p.AssemblyName() == requested {
+				project = p
+			}
+		}
+		if project == nil {
+			return nil, fmt.Errorf("project %s not found (available: %s)", requested, strings.Join(names, ", "))
+		}
+	}
+	if project == nil {
+		return nil, fmt.Errorf("no publishable project found (only test projects)")
+	}
+
+	// Detect .NET version
+	dotnetVersion := DetectDotnetVersion(ctx, project)
+
+	plan := &app.Plan{
+		Provider:        "dotnet",
+		Language:        "dotnet",
+		LanguageVersion: dotnetVersion,
+		PackageManager:  "nuget",
+		DetectedFiles:   detectRelevantFiles(ctx, project),
+	}
+
+	if project.IsWeb() {
+		plan.Framework = "aspnetcore"
+		plan.Runtime.Name = "aspnet"
+	} else {
+		plan.Runtime.Name = "runtime"
+	}
+
+	plan.InstallCommand = fmt.Sprintf("dotnet restore %s", project.Path)
+	plan.BuildCommand = fmt.Sprintf("dotnet publish %s -c Release -o %s --no-restore", project.Path, PublishDir)
+
+	// ASP.NET Core reads --urls from the command line (WebApplication.CreateBuilder(args))
+	dll := project.AssemblyName() + ".dll"
+	if project.IsWeb() {
+		plan.StartCommand = fmt.Sprintf("dotnet %s --urls http://0.0.0.0:$PORT", dll)
+	} else {
+		plan.StartCommand = "dotnet " + dll
+	}
+
+	plan.Runtime.OutputType = "server"
+	plan.DotNet.Project = project.Path
+	plan.Project.Name = project.AssemblyName()
+	if len(projects) > 1 {
+		var paths []string
+		for _, p := range projects {
+			paths = append(paths, p.Path)
+		}
+		plan.DotNet.Projects = paths
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context, project *Project) []string {
+	var files []string
+	for _, pattern := range []string{"*.sln", "*.slnx"} {
+		if matches, err := ctx.ListFiles(pattern); err == nil {
+			files = append(files, matches...)
+		}
+	}
+	files = append(files, project.Path)
+	for _, f := range []string{"global.json", "Directory.Build.props", "Directory.Packages.props", "NuGet.config"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -0,0 +1,191 @@
+package dotnet
+
+import (
+	"encoding/xml"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Project represents an MSBuild project file (.csproj, .fsproj)
+type Project struct {
+	// Path is the project file path relative to the application root
+	Path string `xml:"-"`
+
+	Sdk            string          `xml:"Sdk,attr"`
+	PropertyGroups []PropertyGroup `xml:"PropertyGroup"`
+	ItemGroups     []struct {
+		PackageReferences []struct {
+			Include string `xml:"Include,attr"`
+			Version string `xml:"Version,attr"`
+		} `xml:"PackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// PropertyGroup holds the MSBuild properties Coolpack reads
+type PropertyGroup struct {
+	TargetFramework  string `xml:"TargetFramework"`
+	TargetFrameworks string `xml:"TargetFrameworks"`
+	AssemblyName     string `xml:"AssemblyName"`
+	OutputType       string `xml:"OutputType"`
+	IsTestProject    string `xml:"IsTestProject"`
+}
+
+// slnProjectRegex matches project entries in a .sln file
+// Project("{FAE04EC0-...}") = "Web", "src\Web\Web.csproj", "{...}"
+var slnProjectRegex = regexp.MustCompile(`(?m)^Project\("\{[^}]+\}"\)\s*=\s*"[^"]*",\s*"([^"]+\.[cf]sproj)"`)
+
+// slnxProjectRegex matches project entries in a .slnx file (<Project Path="src/Web/Web.csproj" />)
+var slnxProjectRegex = regexp.MustCompile(`<Project\s+Path="([^"]+\.[cf]sproj)"`)
+
+// ParseProject parses a project file from bytes
+func ParseProject(data []byte) (*Project, error) {
+	var project Project
+	if err := xml.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// property returns the first non-empty value of a property across property groups
+func (p *Project) property(get func(PropertyGroup) string) string {
+	for _, group := range p.PropertyGroups {
+		if v := strings.TrimSpace(get(group)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// TargetFramework returns the target framework moniker (e.g., "net8.0")
+// For multi-targeting projects the last (usually newest) framework is used
+func (p *Project) TargetFramework() string {
+	if tfm := p.property(func(g PropertyGroup) string { return g.TargetFramework }); tfm != "" {
+		return tfm
+	}
+	frameworks := strings.Split(p.property(func(g PropertyGroup) string { return g.TargetFrameworks }), ";")
+	return strings.TrimSpace(frameworks[len(frameworks)-1])
+}
+
+// AssemblyName returns the output assembly name (defaults to the project file name)
+func (p *Project) AssemblyName() string {
+	if name := p.property(func(g PropertyGroup) string { return g.AssemblyName }); name != "" {
+		return name
+	}
+	base := path.Base(p.Path)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// IsWeb checks if the project uses the ASP.NET Core SDK
+func (p *Project) IsWeb() bool {
+	return strings.HasPrefix(p.Sdk, "Microsoft.NET.Sdk.Web") || p.Sdk == "Microsoft.NET.Sdk.Razor"
+}
+
+// IsExecutable checks if the project produces an executable
+func (p *Project) IsExecutable() bool {
+	return p.IsWeb() || strings.EqualFold(p.property(func(g PropertyGroup) string { return g.OutputType }), "exe")
+}
+
+// IsTest checks if the project is a test project
+func (p *Project) IsTest() bool {
+	if strings.EqualFold(p.property(func(g PropertyGroup) string { return g.IsTestProject }), "true") {
+		return true
+	}
+	return p.HasPackage("Microsoft.NET.Test.Sdk")
+}
+
+// HasPackage checks if a NuGet package is referenced
+func (p *Project) HasPackage(name string) bool {
+	for _, group := range p.ItemGroups {
+		for _, ref := range group.PackageReferences {
+			if strings.EqualFold(ref.Include, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// projectPatterns are where project files are looked for when there's no solution
+var projectPatterns = []string{"*.csproj", "*.fsproj", "*/*.csproj", "*/*.fsproj", "src/*/*.csproj", "src/*/*.fsproj"}
+
+// DiscoverProjects finds the projects of the application
+// Projects listed in a root solution file take precedence over a directory scan
+func DiscoverProjects(ctx *app.Context) []*Project {
+	var paths []string
+
+	if solutions, err := ctx.ListFiles("*.sln"); err == nil && len(solutions) > 0 {
+		if data, err := ctx.ReadFile(solutions[0]); err == nil {
+			for _, matches := range slnProjectRegex.FindAllStringSubmatch(string(data), -1) {
+				paths = append(paths, strings.ReplaceAll(matches[1], "\\", "/"))
+			}
+		}
+	} else if solutions, err := ctx.ListFiles("*.slnx"); err == nil && len(solutions) > 0 {
+		if data, err := ctx.ReadFile(solutions[0]); err == nil {
+			for _, matches := range slnxProjectRegex.FindAllStringSubmatch(string(data), -1) {
+				paths = append(paths, strings.ReplaceAll(matches[1], "\\", "/"))
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		seen := make(map[string]bool)
+		for _, pattern := range projectPatterns {
+			files, err := ctx.ListFiles(pattern)
+			if err != nil {
+				continue
+			}
+			for _, f := range files {
+				f = path.Clean(strings.ReplaceAll(f, "\\", "/"))
+				if !seen[f] {
+					seen[f] = true
+					paths = append(paths, f)
+				}
+			}
+		}
+	}
+
+	var projects []*Project
+	for _, p := range paths {
+		data, err := ctx.ReadFile(p)
+		if err != nil {
+			continue
+		}
+		project, err := ParseProject(data)
+		if err != nil {
+			continue
+		}
+		project.Path = p
+		projects = append(projects, project)
+	}
+
+	return projects
+}
+
+// SelectProject picks the project to publish
+// Priority: first ASP.NET Core project, then first executable, then first non-test project
+func SelectProject(projects []*Project) *Project {
+	var candidates []*Project
+	for _, p := range projects {
+		if !p.IsTest() {
+			candidates = append(candidates, p)
+		}
+	}
+
+	for _, p := range candidates {
+		if p.IsWeb() {
+			return p
+		}
+	}
+	for _, p := range candidates {
+		if p.IsExecutable() {
+			return p
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return nil
+}
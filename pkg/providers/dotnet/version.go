@@ -0,0 +1,56 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultDotnetVersion = "10.0"
+
+var (
+	// targetFrameworkRegex extracts the version from a TFM ("net8.0", "netcoreapp3.1", "net9.0-windows")
+	targetFrameworkRegex = regexp.MustCompile(`^net(?:coreapp)?(\d+\.\d+)`)
+
+	// majorMinorRegex extracts major.minor from an SDK version ("8.0.403" -> "8.0")
+	majorMinorRegex = regexp.MustCompile(`^(\d+\.\d+)`)
+)
+
+// DetectDotnetVersion detects the .NET version to use for the SDK and runtime images
+// Priority:
+// 1. COOLPACK_DOTNET_VERSION environment variable
+// 2. TargetFramework of the selected project
+// 3. sdk.version in global.json
+// 4. Default to 10.0
+func DetectDotnetVersion(ctx *app.Context, project *Project) string {
+	// 1. Check COOLPACK_DOTNET_VERSION env var
+	if v := ctx.Env["COOLPACK_DOTNET_VERSION"]; v != "" {
+		return strings.TrimSpace(v)
+	}
+
+	// 2. Check TargetFramework
+	if project != nil {
+		if matches := targetFrameworkRegex.FindStringSubmatch(project.TargetFramework()); len(matches) > 1 {
+			return matches[1]
+		}
+	}
+
+	// 3. Check global.json
+	if data, err := ctx.ReadFile("global.json"); err == nil {
+		var global struct {
+			SDK struct {
+				Version string `json:"version"`
+			} `json:"sdk"`
+		}
+		if json.Unmarshal(data, &global) == nil {
+			if matches := majorMinorRegex.FindStringSubmatch(global.SDK.Version); len(matches) > 1 {
+				return matches[1]
+			}
+		}
+	}
+
+	// 4. Default
+	return DefaultDotnetVersion
+}
@@ -0,0 +1,127 @@
+package elixir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+)
+
+// Provider is the Elixir provider implementation
+type Provider struct{}
+
+// New creates a new Elixir provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "elixir"
+}
+
+// Detect checks if the application is a Mix project
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("mix.exs") {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found mix.exs")
+	if data, err := ctx.ReadFile("mix.exs"); err == nil && strings.Contains(string(data), ":phoenix") {
+		detection.Raise(app.ConfidenceApp, "depends on Phoenix")
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the Elixir application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	data, err := ctx.ReadFile("mix.exs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mix.exs: %w", err)
+	}
+	project := ParseMixProject(data)
+
+	release := project.ReleaseName()
+	if release == "" {
+		return nil, fmt.Errorf("could not determine the release name (no app: or releases: in mix.exs)")
+	}
+
+	locked := make(map[string]string)
+	if data, err := ctx.ReadFile("mix.lock"); err == nil {
+		locked = ParseMixLock(data)
+	}
+
+	// Detect Elixir and Erlang/OTP versions
+	elixirVersion, erlangVersion := DetectVersions(ctx)
+
+	plan := &app.Plan{
+		Provider:        "elixir",
+		Language:        "elixir",
+		LanguageVersion: elixirVersion,
+		PackageManager:  "mix",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	plan.InstallCommand = "mix deps.get --only prod"
+
+	// Compile dependencies, build assets, then compile the app and assemble the release
+	buildSteps := []string{"mix deps.compile"}
+	if project.Deps["phoenix"] {
+		plan.Framework = "phoenix"
+		plan.FrameworkVersion = locked["phoenix"]
+
+		// Projects that still build assets with npm need Node.js in the builder
+		if ctx.HasFile("assets/package.json") {
+			pkg := &node.PackageJSON{}
+			if data, err := ctx.ReadFile("assets/package.json"); err == nil {
+				if parsed, err := node.ParsePackageJSON(data); err == nil {
+					pkg = parsed
+				}
+			}
+			nodeVersion := node.DetectNodeVersion(ctx, pkg)
+			plan.Tools.NodeVersion = nodeVersion.Version
+			plan.SetSource("tools.node_version", nodeVersion.Source)
+			plan.Tools.NodePackageManager = "npm"
+			buildSteps = append(buildSteps, "npm install --prefix assets")
+		}
+		if project.HasAssetsDeploy {
+			buildSteps = append(buildSteps, "mix assets.deploy")
+		}
+	}
+	buildSteps = append(buildSteps, "mix compile", "mix release "+release)
+	plan.BuildCommand = strings.Join(buildSteps, " && ")
+
+	// Releases ship a start script that boots the VM with the bundled ERTS
+	plan.StartCommand = fmt.Sprintf("/app/bin/%s start", release)
+
+	// NIFs (and rebar3 deps) are compiled in the builder
+	plan.Packages.Apt = []string{"build-essential", "git"}
+
+	plan.Runtime.OutputType = "server"
+	plan.Elixir.ErlangVersion = erlangVersion
+	plan.Elixir.Release = release
+	if project.IsUmbrella {
+		plan.Elixir.IsUmbrella = true
+	}
+	if project.App != "" {
+		plan.Project.Name = project.App
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range []string{"mix.exs", "mix.lock", ".tool-versions", "config/runtime.exs", "rel/env.sh.eex", "assets/package.json"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
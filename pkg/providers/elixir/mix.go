@@ -0,0 +1,82 @@
+package elixir
+
+import (
+	"regexp"
+)
+
+// MixProject represents the parts of mix.exs that Coolpack cares about
+// mix.exs is Elixir code, so values are extracted with patterns for the generated layout
+type MixProject struct {
+	// App is the OTP application name (app: :my_app)
+	App string
+
+	// Deps lists the dependency names ({:phoenix, "~> 1.7"})
+	Deps map[string]bool
+
+	// Releases lists the release names from the releases: [...] config
+	Releases []string
+
+	// IsUmbrella is true for umbrella projects (apps_path: "apps")
+	IsUmbrella bool
+
+	// HasAssetsDeploy is true if an "assets.deploy" alias is defined
+	HasAssetsDeploy bool
+}
+
+var (
+	// appRegex matches app: :my_app
+	appRegex = regexp.MustCompile(`\bapp:\s*:(\w+)`)
+
+	// depRegex matches {:dep, ...} tuples
+	depRegex = regexp.MustCompile(`\{\s*:(\w+)\s*,`)
+
+	// releasesRegex matches the first release name in releases: [my_app: [...]]
+	releasesRegex = regexp.MustCompile(`releases:\s*\[\s*(\w+):`)
+
+	// umbrellaRegex matches apps_path: "apps"
+	umbrellaRegex = regexp.MustCompile(`\bapps_path:\s*"`)
+
+	// assetsDeployRegex matches the "assets.deploy" alias
+	assetsDeployRegex = regexp.MustCompile(`"assets\.deploy"\s*:`)
+
+	// lockVersionRegex matches "phoenix": {:hex, :phoenix, "1.7.14", ...} in mix.lock
+	lockVersionRegex = regexp.MustCompile(`"(\w+)":\s*\{:hex,\s*:\w+,\s*"([^"]+)"`)
+)
+
+// ParseMixProject parses mix.exs from bytes
+func ParseMixProject(data []byte) *MixProject {
+	content := string(data)
+	project := &MixProject{Deps: make(map[string]bool)}
+
+	if matches := appRegex.FindStringSubmatch(content); len(matches) > 1 {
+		project.App = matches[1]
+	}
+	for _, matches := range depRegex.FindAllStringSubmatch(content, -1) {
+		project.Deps[matches[1]] = true
+	}
+	if matches := releasesRegex.FindStringSubmatch(content); len(matches) > 1 {
+		project.Releases = append(project.Releases, matches[1])
+	}
+	project.IsUmbrella = umbrellaRegex.MatchString(content)
+	project.HasAssetsDeploy = assetsDeployRegex.MatchString(content)
+
+	return project
+}
+
+// ParseMixLock returns the locked Hex package versions from mix.lock
+func ParseMixLock(data []byte) map[string]string {
+	versions := make(map[string]string)
+	for _, matches := range lockVersionRegex.FindAllSubmatch(data, -1) {
+		versions[string(matches[1])] = string(matches[2])
+	}
+	return versions
+}
+
+// ReleaseName returns the name of the release to build
+// The first configured release wins, otherwise mix names it after the app
+func (p *MixProject) ReleaseName() string {
+	if len(p.Releases) > 0 {
+		return p.Releases[0]
+	}
+	return p.App
+}
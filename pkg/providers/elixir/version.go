@@ -0,0 +1,72 @@
+package elixir
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const (
+	DefaultElixirVersion = "1.18"
+	DefaultErlangVersion = "27"
+)
+
+var (
+	// elixirOTPRegex splits an asdf Elixir version ("1.17.3-otp-27") into Elixir and OTP
+	elixirOTPRegex = regexp.MustCompile(`^([\d.]+)(?:-otp-(\d+))?`)
+
+	// otpMajorRegex extracts the OTP release from an Erlang version ("27.1.2" -> "27")
+	otpMajorRegex = regexp.MustCompile(`^(\d+)`)
+)
+
+// DetectVersions detects the Elixir and Erlang/OTP versions to use
+// Priority (Elixir):
+// 1. COOLPACK_ELIXIR_VERSION environment variable
+// 2. elixir in .tool-versions
+// 3. Default to 1.18 (mix.exs requirements like "~> 1.15" are open-ended)
+// Priority (Erlang/OTP):
+// 1. COOLPACK_ERLANG_VERSION environment variable
+// 2. erlang in .tool-versions
+// 3. OTP suffix of the .tool-versions elixir entry (1.17.3-otp-27)
+// 4. Default to 27
+func DetectVersions(ctx *app.Context) (elixirVersion, erlangVersion string) {
+	var toolOTP string
+
+	// 1. Check COOLPACK_ELIXIR_VERSION env var
+	if v := ctx.Env["COOLPACK_ELIXIR_VERSION"]; v != "" {
+		elixirVersion = strings.TrimSpace(v)
+	}
+
+	// 2. Check .tool-versions file (asdf format)
+	if v := ctx.ReadToolVersion("elixir"); v != "" {
+		if matches := elixirOTPRegex.FindStringSubmatch(v); len(matches) > 2 {
+			if elixirVersion == "" {
+				elixirVersion = matches[1]
+			}
+			toolOTP = matches[2]
+		}
+	}
+
+	// 3. Default
+	if elixirVersion == "" {
+		elixirVersion = DefaultElixirVersion
+	}
+
+	// Erlang/OTP, only the major release is needed to pick the image
+	switch {
+	case ctx.Env["COOLPACK_ERLANG_VERSION"] != "":
+		erlangVersion = strings.TrimSpace(ctx.Env["COOLPACK_ERLANG_VERSION"])
+	case ctx.ReadToolVersion("erlang") != "":
+		erlangVersion = ctx.ReadToolVersion("erlang")
+	case toolOTP != "":
+		erlangVersion = toolOTP
+	default:
+		erlangVersion = DefaultErlangVersion
+	}
+	if matches := otpMajorRegex.FindStringSubmatch(erlangVersion); len(matches) > 1 {
+		erlangVersion = matches[1]
+	}
+
+	return elixirVersion, erlangVersion
+}
@@ -0,0 +1,61 @@
+package golang
+
+import (
+	"regexp"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// cgoModules are well-known modules that require cgo to build
+var cgoModules = []string{
+	"github.com/smacker/go-tree-sitter",
+	"github.com/tree-sitter/go-tree-sitter",
+	"github.com/mattn/go-sqlite3",
+	"github.com/confluentinc/confluent-kafka-go",
+	"github.com/go-gl/glfw",
+	"github.com/google/gopacket",
+	"github.com/tecbot/gorocksdb",
+	"github.com/linxGnu/grocksdb",
+	"gopkg.in/gographics/imagick.v3",
+	"github.com/h2non/bimg",
+	"github.com/davidbyttow/govips",
+}
+
+// importCRegex matches import "C" in Go source files
+var importCRegex = regexp.MustCompile(`(?m)^\s*import\s+"C"\s*$`)
+
+// cgoSourcePatterns are the local source files scanned for import "C"
+var cgoSourcePatterns = []string{
+	"*.go",
+	"*/*.go",
+	"*/*/*.go",
+}
+
+// DetectCgo checks if the module needs cgo, either through a known cgo
+// dependency or a local import "C"
+// Returns the reason, or an empty string if cgo isn't needed
+func DetectCgo(ctx *app.Context, mod *GoMod) string {
+	for _, module := range cgoModules {
+		if mod.HasRequire(module) {
+			return module
+		}
+	}
+
+	for _, pattern := range cgoSourcePatterns {
+		files, err := ctx.ListFiles(pattern)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			data, err := ctx.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			if importCRegex.Match(data) {
+				return file
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,133 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// BinaryPath is where the compiled binary is placed in the image
+const BinaryPath = "/app/server"
+
+// Provider is the Go provider implementation
+type Provider struct{}
+
+// New creates a new Go provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "go"
+}
+
+// Detect checks if the application is a Go module
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("go.mod") {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found go.mod")
+	if packages := DetectMainPackages(ctx); len(packages) > 0 {
+		detection.Raise(app.ConfidenceApp, "main package in "+packages[0])
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the Go application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	data, err := ctx.ReadFile("go.mod")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	mod := ParseGoMod(data)
+
+	// Detect Go version
+	goVersion := DetectGoVersion(ctx, mod)
+
+	// Detect main packages
+	mainPackages := DetectMainPackages(ctx)
+	if len(mainPackages) == 0 {
+		return nil, fmt.Errorf("no main package found (looked in the module root and cmd/*)")
+	}
+
+	// Select the package to build (COOLPACK_GO_MAIN_PACKAGE > auto-detected)
+	mainPackage := SelectMainPackage(mainPackages, mod.ModuleName())
+	if requested := ctx.Env["COOLPACK_GO_MAIN_PACKAGE"]; requested != "" {
+		mainPackage = NormalizePackageTarget(requested)
+		if !contains(mainPackages, mainPackage) {
+			return nil, fmt.Errorf("main package %s not found (available: %s)", mainPackage, strings.Join(mainPackages, ", "))
+		}
+	}
+
+	plan := &app.Plan{
+		Provider:        "go",
+		Language:        "go",
+		LanguageVersion: goVersion,
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	// Determine install command (vendored modules don't need downloading)
+	if !ctx.HasFile("vendor/modules.txt") {
+		plan.InstallCommand = "go mod download"
+	}
+
+	// Build a static binary from the selected main package
+	// cgo binaries are linked statically so they still run on distroless/static or scratch
+	cgoReason := DetectCgo(ctx, mod)
+	if cgoReason != "" {
+		plan.BuildCommand = fmt.Sprintf("CGO_ENABLED=1 go build -trimpath -tags netgo,osusergo -ldflags=\"-s -w -linkmode external -extldflags -static\" -o %s %s", BinaryPath, mainPackage)
+		plan.Go.CGO = cgoReason
+	} else {
+		plan.BuildCommand = fmt.Sprintf("CGO_ENABLED=0 go build -trimpath -ldflags=\"-s -w\" -o %s %s", BinaryPath, mainPackage)
+	}
+	plan.StartCommand = BinaryPath
+
+	plan.Runtime.OutputType = "server"
+	plan.Go.MainPackage = mainPackage
+	if len(mainPackages) > 1 {
+		plan.Go.MainPackages = mainPackages
+	}
+	if mod.Module != "" {
+		plan.Go.Module = mod.Module
+	}
+
+	// Runner image: distroless (default) or scratch
+	runner := "distroless"
+	if r := ctx.Env["COOLPACK_GO_RUNNER"]; r != "" {
+		runner = r
+	}
+	if runner != "distroless" && runner != "scratch" {
+		return nil, fmt.Errorf("unsupported Go runner %q (expected distroless or scratch)", runner)
+	}
+	plan.Runtime.Runner = runner
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range []string{"go.mod", "go.sum", "go.work", "vendor/modules.txt", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// contains checks if a string slice contains a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
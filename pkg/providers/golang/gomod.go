@@ -0,0 +1,96 @@
+package golang
+
+import (
+	"bufio"
+	"strings"
+)
+
+// GoMod represents the parts of go.mod that Coolpack cares about
+type GoMod struct {
+	// Module is the module path (e.g., "github.com/coollabsio/coolpack")
+	Module string
+
+	// GoVersion is the go directive (e.g., "1.22" or "1.22.3")
+	GoVersion string
+
+	// Toolchain is the toolchain directive without the "go" prefix (e.g., "1.22.5")
+	Toolchain string
+
+	// Requires lists the module paths of all required modules (direct and indirect)
+	Requires []string
+}
+
+// ParseGoMod parses a go.mod file from bytes
+// Only the module, go, toolchain and require directives are read
+func ParseGoMod(data []byte) *GoMod {
+	mod := &GoMod{}
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// Strip comments
+		if idx := strings.Index(line, "//"); idx != -1 {
+			line = line[:idx]
+		}
+		fields := strings.Fields(line)
+
+		// Inside a require ( ... ) block every line is "path version"
+		if inRequireBlock {
+			if len(fields) > 0 && fields[0] == ")" {
+				inRequireBlock = false
+			} else if len(fields) >= 2 {
+				mod.Requires = append(mod.Requires, fields[0])
+			}
+			continue
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "require":
+			if fields[1] == "(" {
+				inRequireBlock = true
+			} else {
+				mod.Requires = append(mod.Requires, fields[1])
+			}
+		case "module":
+			mod.Module = strings.Trim(fields[1], `"`)
+		case "go":
+			mod.GoVersion = fields[1]
+		case "toolchain":
+			mod.Toolchain = strings.TrimPrefix(fields[1], "go")
+		}
+	}
+
+	return mod
+}
+
+// HasRequire checks if a module is required (exactly or as a sub-module path)
+func (m *GoMod) HasRequire(module string) bool {
+	for _, req := range m.Requires {
+		if req == module || strings.HasPrefix(req, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ModuleName returns the last element of the module path
+// "github.com/coollabsio/coolpack" -> "coolpack", "example.com/api/v2" -> "api"
+func (m *GoMod) ModuleName() string {
+	parts := strings.Split(m.Module, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := parts[i]
+		// Skip major version suffixes
+		if len(part) > 1 && part[0] == 'v' && strings.Trim(part[1:], "0123456789") == "" {
+			continue
+		}
+		if part != "" {
+			return part
+		}
+	}
+	return ""
+}
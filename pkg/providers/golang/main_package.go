@@ -0,0 +1,104 @@
+package golang
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// packageMainRegex matches the package clause of a main package
+var packageMainRegex = regexp.MustCompile(`(?m)^package\s+main\s*$`)
+
+// mainPackagePatterns are the directories searched for main packages
+var mainPackagePatterns = []string{
+	"*.go",
+	"cmd/*/*.go",
+}
+
+// DetectMainPackages returns the main packages of the module as build targets
+// (e.g., ".", "./cmd/api", "./cmd/worker"), sorted with the root package first
+func DetectMainPackages(ctx *app.Context) []string {
+	seen := make(map[string]bool)
+	var packages []string
+
+	for _, pattern := range mainPackagePatterns {
+		files, err := ctx.ListFiles(pattern)
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			// Test files never make a package a main package
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+
+			dir := path.Dir(file)
+			if seen[dir] {
+				continue
+			}
+
+			data, err := ctx.ReadFile(file)
+			if err != nil || !packageMainRegex.Match(data) {
+				continue
+			}
+
+			seen[dir] = true
+			packages = append(packages, packageTarget(dir))
+		}
+	}
+
+	sort.SliceStable(packages, func(i, j int) bool {
+		if packages[i] == "." || packages[j] == "." {
+			return packages[i] == "."
+		}
+		return packages[i] < packages[j]
+	})
+
+	return packages
+}
+
+// SelectMainPackage picks the package to build when none was requested
+// Priority:
+// 1. The root package
+// 2. cmd/<module name>
+// 3. The first cmd/* package
+func SelectMainPackage(packages []string, moduleName string) string {
+	if len(packages) == 0 {
+		return ""
+	}
+
+	for _, pkg := range packages {
+		if pkg == "." {
+			return pkg
+		}
+	}
+
+	if moduleName != "" {
+		for _, pkg := range packages {
+			if pkg == "./cmd/"+moduleName {
+				return pkg
+			}
+		}
+	}
+
+	return packages[0]
+}
+
+// NormalizePackageTarget converts a user-supplied package ("cmd/api", "./cmd/api/")
+// into a go build target
+func NormalizePackageTarget(pkg string) string {
+	pkg = strings.TrimSuffix(strings.TrimSpace(pkg), "/")
+	return packageTarget(strings.TrimPrefix(pkg, "./"))
+}
+
+// packageTarget converts a relative directory into a go build target
+func packageTarget(dir string) string {
+	if dir == "." || dir == "" {
+		return "."
+	}
+	return "./" + dir
+}
@@ -0,0 +1,52 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultGoVersion = "1.25"
+
+// DetectGoVersion detects the Go version used for the builder image
+// Priority:
+// 1. COOLPACK_GO_VERSION environment variable
+// 2. toolchain directive in go.mod
+// 3. go directive in go.mod
+// 4. .tool-versions file (asdf)
+// 5. Default to 1.25
+func DetectGoVersion(ctx *app.Context, mod *GoMod) string {
+	// 1. Check COOLPACK_GO_VERSION env var
+	if v := ctx.Env["COOLPACK_GO_VERSION"]; v != "" {
+		return strings.TrimPrefix(strings.TrimSpace(v), "go")
+	}
+
+	// 2. Check toolchain directive (the exact toolchain the module was built with)
+	if mod.Toolchain != "" && mod.Toolchain != "default" {
+		return mod.Toolchain
+	}
+
+	// 3. Check go directive (the minimum version required)
+	if mod.GoVersion != "" {
+		return normalizeGoDirective(mod.GoVersion)
+	}
+
+	// 4. Check .tool-versions file (asdf format)
+	if v := ctx.ReadToolVersion("golang"); v != "" {
+		return v
+	}
+
+	// 5. Default
+	return DefaultGoVersion
+}
+
+// normalizeGoDirective converts a go directive into an image tag
+// Since Go 1.21, "go 1.22.0" is a full release version, while "go 1.22" refers to
+// the 1.22 language version; both map to the golang:1.22 image unless a patch is set
+func normalizeGoDirective(v string) string {
+	parts := strings.Split(v, ".")
+	if len(parts) == 3 && parts[2] == "0" {
+		return parts[0] + "." + parts[1]
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+package hugo
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// configFiles are the Hugo site configuration files, in the order Hugo looks them up
+// hugo.* replaced config.* in v0.110.0, config/_default/ is the config directory form
+var configFiles = []string{
+	"hugo.toml", "hugo.yaml", "hugo.yml", "hugo.json",
+	"config.toml", "config.yaml", "config.yml", "config.json",
+	"config/_default/hugo.toml", "config/_default/hugo.yaml", "config/_default/hugo.yml", "config/_default/hugo.json",
+	"config/_default/config.toml", "config/_default/config.yaml", "config/_default/config.yml", "config/_default/config.json",
+}
+
+// siteDirs are directories only Hugo sites have, used to tell a generic config.toml apart
+var siteDirs = []string{"archetypes", "themes", "layouts"}
+
+// hugoVersionRegex matches the minimum version in the module.hugoVersion section
+// of a TOML, YAML or JSON config ([module.hugoVersion] min = "0.120.0")
+var hugoVersionRegex = regexp.MustCompile(`hugoVersion["']?\]?\s*[:=]?\s*\{?[^\[]*?\bmin["']?\s*[:=]\s*["']?v?(\d+\.\d+(?:\.\d+)?)`)
+
+// NetlifyConfig represents the parts of netlify.toml that pin the Hugo version
+type NetlifyConfig struct {
+	Build struct {
+		Environment map[string]string `toml:"environment"`
+	} `toml:"build"`
+	Context map[string]struct {
+		Environment map[string]string `toml:"environment"`
+	} `toml:"context"`
+}
+
+// FindConfigFile returns the site configuration file, or "" if there is none
+// hugo.* files identify a Hugo site on their own; config.* files only count
+// next to Hugo's archetypes/, themes/ or layouts/ directories
+func FindConfigFile(ctx *app.Context) string {
+	for _, f := range configFiles {
+		if !ctx.HasFile(f) {
+			continue
+		}
+		if strings.HasPrefix(path.Base(f), "hugo.") {
+			return f
+		}
+		for _, dir := range siteDirs {
+			if ctx.HasFile(dir) {
+				return f
+			}
+		}
+	}
+	return ""
+}
+
+// configMinVersion returns the module.hugoVersion.min setting of the site config
+func configMinVersion(ctx *app.Context, configFile string) string {
+	data, err := ctx.ReadFile(configFile)
+	if err != nil {
+		return ""
+	}
+	if matches := hugoVersionRegex.FindSubmatch(data); len(matches) > 1 {
+		return string(matches[1])
+	}
+	return ""
+}
+
+// netlifyVersion returns HUGO_VERSION from netlify.toml (production context first)
+func netlifyVersion(ctx *app.Context) string {
+	data, err := ctx.ReadFile("netlify.toml")
+	if err != nil {
+		return ""
+	}
+	var config NetlifyConfig
+	if _, err := toml.Decode(string(data), &config); err != nil {
+		return ""
+	}
+	if v := config.Context["production"].Environment["HUGO_VERSION"]; v != "" {
+		return v
+	}
+	return config.Build.Environment["HUGO_VERSION"]
+}
@@ -0,0 +1,99 @@
+package hugo
+
+import (
+	"fmt"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/golang"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+)
+
+// Provider is the Hugo provider implementation
+type Provider struct{}
+
+// New creates a new Hugo provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "hugo"
+}
+
+// Detect checks if the application is a Hugo site
+// Hugo sites outrank Node.js and Go, since they use package.json for PostCSS
+// and go.mod for Hugo Modules
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	configFile := FindConfigFile(ctx)
+	if configFile == "" {
+		return app.NoMatch(), nil
+	}
+	return app.Detected(app.ConfidenceSpecific, "found Hugo config "+configFile), nil
+}
+
+// Plan generates a build plan for the Hugo site
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	configFile := FindConfigFile(ctx)
+	if configFile == "" {
+		return nil, fmt.Errorf("no Hugo site configuration found")
+	}
+
+	hugoVersion := DetectHugoVersion(ctx, configFile)
+
+	plan := &app.Plan{
+		Provider:         "hugo",
+		Language:         "hugo",
+		LanguageVersion:  hugoVersion,
+		Framework:        "hugo",
+		FrameworkVersion: hugoVersion,
+		DetectedFiles:    detectRelevantFiles(ctx, configFile),
+	}
+
+	// The extended edition is a superset (Sass, WebP), so it's always used
+	plan.BuildCommand = "hugo --gc --minify"
+
+	// curl downloads Hugo, git is needed by Hugo Modules and enableGitInfo
+	plan.Packages.Apt = []string{"ca-certificates", "curl", "git"}
+
+	// Hugo Modules are resolved with the Go toolchain
+	if data, err := ctx.ReadFile("go.mod"); err == nil {
+		plan.Tools.GoVersion = golang.DetectGoVersion(ctx, golang.ParseGoMod(data))
+	}
+
+	// PostCSS, Tailwind and friends are installed from package.json before building
+	if data, err := ctx.ReadFile("package.json"); err == nil {
+		if pkg, err := node.ParsePackageJSON(data); err == nil {
+			pmInfo := node.DetectPackageManager(ctx, pkg)
+			plan.PackageManager = string(pmInfo.Name)
+			plan.InstallCommand = pmInfo.GetInstallCommand()
+			nodeVersion := node.DetectNodeVersion(ctx, pkg)
+			plan.Tools.NodeVersion = nodeVersion.Version
+			plan.SetSource("tools.node_version", nodeVersion.Source)
+			plan.Tools.NodePackageManager = string(pmInfo.Name)
+		}
+	}
+
+	// Hugo sites are generated into public/ and served as static files
+	plan.Runtime.OutputType = "static"
+	plan.Static.OutputDir = "public"
+	plan.SetSource("static.output_dir", "hugo default")
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context, configFile string) []string {
+	files := []string{configFile}
+	for _, f := range []string{"go.mod", "package.json", "netlify.toml", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
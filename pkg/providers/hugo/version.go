@@ -0,0 +1,47 @@
+package hugo
+
+import (
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultHugoVersion = "0.147.0"
+
+// DetectHugoVersion detects the Hugo release to install
+// Priority:
+// 1. COOLPACK_HUGO_VERSION environment variable
+// 2. module.hugoVersion.min in the site config
+// 3. HUGO_VERSION in netlify.toml
+// 4. .tool-versions file (asdf)
+// 5. Default to 0.147.0
+func DetectHugoVersion(ctx *app.Context, configFile string) string {
+	// 1. Check COOLPACK_HUGO_VERSION env var
+	if v := ctx.Env["COOLPACK_HUGO_VERSION"]; v != "" {
+		return normalizeVersion(v)
+	}
+
+	// 2. Check the minimum version the site requires
+	if v := configMinVersion(ctx, configFile); v != "" {
+		return v
+	}
+
+	// 3. Check netlify.toml (the usual place Hugo sites pin their version)
+	if v := netlifyVersion(ctx); v != "" {
+		return normalizeVersion(v)
+	}
+
+	// 4. Check .tool-versions file (asdf format)
+	if v := ctx.ReadToolVersion("hugo"); v != "" {
+		return normalizeVersion(v)
+	}
+
+	// 5. Default
+	return DefaultHugoVersion
+}
+
+// normalizeVersion strips the v prefix and the extended_ marker asdf uses ("extended_0.125.4")
+func normalizeVersion(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	return strings.TrimPrefix(v, "extended_")
+}
@@ -0,0 +1,75 @@
+package java
+
+// Framework represents a detected JVM framework
+type Framework string
+
+const (
+	FrameworkNone       Framework = ""
+	FrameworkSpringBoot Framework = "spring-boot"
+	FrameworkQuarkus    Framework = "quarkus"
+	FrameworkMicronaut  Framework = "micronaut"
+)
+
+// FrameworkInfo contains information about the detected framework
+type FrameworkInfo struct {
+	Name    Framework
+	Version string
+}
+
+// DetectFramework detects the framework used by the project
+func DetectFramework(pom *POM, gradle *GradleBuild) FrameworkInfo {
+	info := FrameworkInfo{
+		Name: FrameworkNone,
+	}
+
+	if pom != nil {
+		switch {
+		case pom.HasGroup("org.springframework.boot"):
+			info.Name = FrameworkSpringBoot
+			info.Version = pom.VersionOf("org.springframework.boot")
+		case pom.HasGroup("io.quarkus"):
+			info.Name = FrameworkQuarkus
+			info.Version = pom.Property("quarkus.platform.version")
+			if info.Version == "" {
+				info.Version = pom.VersionOf("io.quarkus.platform")
+			}
+		case pom.HasGroup("io.micronaut"):
+			info.Name = FrameworkMicronaut
+			info.Version = pom.Property("micronaut.version")
+			if info.Version == "" {
+				info.Version = pom.VersionOf("io.micronaut.platform")
+			}
+		}
+		return info
+	}
+
+	if gradle != nil {
+		switch {
+		case gradle.HasPlugin("org.springframework.boot"):
+			info.Name = FrameworkSpringBoot
+			info.Version = gradle.Plugins["org.springframework.boot"]
+		case gradle.HasPlugin("io.quarkus") || gradle.References("io.quarkus"):
+			info.Name = FrameworkQuarkus
+			info.Version = gradle.Plugins["io.quarkus"]
+		case gradle.HasPlugin("io.micronaut.application") || gradle.References("io.micronaut"):
+			info.Name = FrameworkMicronaut
+			info.Version = gradle.Plugins["io.micronaut.application"]
+		}
+	}
+
+	return info
+}
+
+// PortFlag returns the system property that sets the HTTP port
+// None of these frameworks read PORT on their own
+func (f FrameworkInfo) PortFlag() string {
+	switch f.Name {
+	case FrameworkSpringBoot:
+		return "-Dserver.port=$PORT"
+	case FrameworkQuarkus:
+		return "-Dquarkus.http.port=$PORT"
+	case FrameworkMicronaut:
+		return "-Dmicronaut.server.port=$PORT"
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package java
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// GradleBuild represents the parts of build.gradle(.kts) that Coolpack cares about
+// Gradle scripts are code, so values are extracted with patterns for the common DSL forms
+type GradleBuild struct {
+	// File is the build script that was read (build.gradle or build.gradle.kts)
+	File string
+
+	// ToolchainVersion is the Java version from java { toolchain { ... } } or jvmToolchain(...)
+	ToolchainVersion string
+
+	// SourceCompatibility is the Java version from sourceCompatibility
+	SourceCompatibility string
+
+	// Plugins lists the plugin IDs applied in the plugins block or with apply plugin
+	Plugins map[string]string
+
+	content string
+}
+
+var (
+	// toolchainRegex matches JavaLanguageVersion.of(21) in a toolchain block
+	toolchainRegex = regexp.MustCompile(`JavaLanguageVersion\.of\(\s*["']?(\d+)["']?\s*\)`)
+
+	// jvmToolchainRegex matches the Kotlin shorthand jvmToolchain(21)
+	jvmToolchainRegex = regexp.MustCompile(`jvmToolchain\(\s*(\d+)\s*\)`)
+
+	// sourceCompatibilityRegex matches sourceCompatibility = '17', JavaVersion.VERSION_17 or VERSION_1_8
+	sourceCompatibilityRegex = regexp.MustCompile(`sourceCompatibility\s*=?\s*(?:JavaVersion\.VERSION_([\d_]+)|["']?([\d.]+)["']?)`)
+
+	// pluginRegex matches id("x") / id 'x' with an optional version
+	pluginRegex = regexp.MustCompile(`\bid\s*\(?\s*["']([\w.\-]+)["']\s*\)?(?:\s*version\s*\(?\s*["']([^"']+)["'])?`)
+
+	// applyPluginRegex matches apply plugin: 'x'
+	applyPluginRegex = regexp.MustCompile(`apply\s+plugin\s*:\s*["']([\w.\-]+)["']`)
+
+	// kotlinPluginRegex matches kotlin("jvm") style plugins
+	kotlinPluginRegex = regexp.MustCompile(`\bkotlin\(\s*"([\w.\-]+)"\s*\)`)
+)
+
+// LoadGradleBuild reads build.gradle.kts or build.gradle, returning nil if neither exists
+func LoadGradleBuild(ctx *app.Context) *GradleBuild {
+	for _, file := range []string{"build.gradle.kts", "build.gradle"} {
+		data, err := ctx.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		build := ParseGradleBuild(string(data))
+		build.File = file
+		return build
+	}
+	return nil
+}
+
+// ParseGradleBuild parses a Gradle build script
+func ParseGradleBuild(content string) *GradleBuild {
+	build := &GradleBuild{
+		Plugins: make(map[string]string),
+		content: content,
+	}
+
+	if matches := toolchainRegex.FindStringSubmatch(content); len(matches) > 1 {
+		build.ToolchainVersion = matches[1]
+	} else if matches := jvmToolchainRegex.FindStringSubmatch(content); len(matches) > 1 {
+		build.ToolchainVersion = matches[1]
+	}
+
+	if matches := sourceCompatibilityRegex.FindStringSubmatch(content); len(matches) > 2 {
+		if matches[1] != "" {
+			build.SourceCompatibility = strings.ReplaceAll(matches[1], "_", ".")
+		} else {
+			build.SourceCompatibility = matches[2]
+		}
+	}
+
+	for _, matches := range pluginRegex.FindAllStringSubmatch(content, -1) {
+		build.Plugins[matches[1]] = matches[2]
+	}
+	for _, matches := range applyPluginRegex.FindAllStringSubmatch(content, -1) {
+		build.Plugins[matches[1]] = ""
+	}
+	for _, matches := range kotlinPluginRegex.FindAllStringSubmatch(content, -1) {
+		build.Plugins["org.jetbrains.kotlin."+matches[1]] = ""
+	}
+
+	return build
+}
+
+// HasPlugin checks if a plugin is applied
+func (b *GradleBuild) HasPlugin(id string) bool {
+	_, ok := b.Plugins[id]
+	return ok
+}
+
+// References checks if the build script mentions a dependency group or coordinate
+func (b *GradleBuild) References(s string) bool {
+	return strings.Contains(b.content, s)
+}
@@ -0,0 +1,174 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// JarPath is where the runnable jar is placed in the image
+const JarPath = "/app/app.jar"
+
+// Provider is the Java provider implementation
+type Provider struct{}
+
+// New creates a new Java provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "java"
+}
+
+// detectFiles are the files that identify a Maven or Gradle project
+var detectFiles = []string{
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"mvnw",
+	"gradlew",
+}
+
+// Detect checks if the application is a Maven or Gradle project
+// Build files rank as an application: builds using frontend-maven-plugin
+// also have a root package.json, which shouldn't win
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	for _, f := range detectFiles {
+		if ctx.HasFile(f) {
+			return app.Detected(app.ConfidenceApp, "found "+f), nil
+		}
+	}
+	return app.NoMatch(), nil
+}
+
+// Plan generates a build plan for the Java application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	var pom *POM
+	if data, err := ctx.ReadFile("pom.xml"); err == nil {
+		pom, err = ParsePOM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pom.xml: %w", err)
+		}
+	}
+	gradle := LoadGradleBuild(ctx)
+
+	// Maven wins when both build files are present
+	buildTool := "gradle"
+	if pom != nil || (gradle == nil && ctx.HasFile("mvnw")) {
+		buildTool = "maven"
+	}
+
+	// Detect Java version
+	javaVersion := DetectJavaVersion(ctx, pom, gradle)
+
+	// Detect framework
+	var fwInfo FrameworkInfo
+	if buildTool == "maven" {
+		fwInfo = DetectFramework(pom, nil)
+	} else {
+		fwInfo = DetectFramework(nil, gradle)
+	}
+
+	plan := &app.Plan{
+		Provider:        "java",
+		Language:        "java",
+		LanguageVersion: javaVersion,
+		PackageManager:  buildTool,
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	// Add framework info
+	if fwInfo.Name != FrameworkNone {
+		plan.Framework = string(fwInfo.Name)
+		plan.FrameworkVersion = fwInfo.Version
+	}
+
+	// Prefer the project's wrapper, which pins the build tool version
+	wrapper := ""
+	if buildTool == "maven" && ctx.HasFile("mvnw") {
+		wrapper = "mvnw"
+	} else if buildTool == "gradle" && ctx.HasFile("gradlew") {
+		wrapper = "gradlew"
+	}
+	if wrapper != "" {
+		plan.Java.Wrapper = wrapper
+	}
+
+	buildCommand, artifact := determineBuild(buildTool, wrapper, fwInfo, gradle)
+	plan.BuildCommand = buildCommand
+	plan.Java.Artifact = artifact
+
+	// Run the jar with the framework's port property
+	jar := JarPath
+	if fwInfo.Name == FrameworkQuarkus {
+		jar = "/app/quarkus-app/quarkus-run.jar"
+	}
+	if flag := fwInfo.PortFlag(); flag != "" {
+		plan.StartCommand = fmt.Sprintf("java %s -jar %s", flag, jar)
+	} else {
+		plan.StartCommand = "java -jar " + jar
+	}
+
+	plan.Runtime.OutputType = "server"
+
+	if pom != nil && pom.ArtifactID != "" {
+		plan.Project.Name = pom.ArtifactID
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// determineBuild returns the build command and the build output to copy into the runner
+// The output is a jar glob, or the quarkus-app directory for Quarkus' fast-jar layout
+func determineBuild(buildTool, wrapper string, fw FrameworkInfo, gradle *GradleBuild) (string, string) {
+	if buildTool == "maven" {
+		mvn := "mvn"
+		if wrapper != "" {
+			mvn = "./mvnw"
+		}
+		cmd := mvn + " -B -DskipTests package"
+		if fw.Name == FrameworkQuarkus {
+			return cmd, "target/quarkus-app"
+		}
+		return cmd, "target/*.jar"
+	}
+
+	gradlew := "gradle"
+	if wrapper != "" {
+		gradlew = "./gradlew"
+	}
+	hasShadow := gradle != nil && (gradle.HasPlugin("com.github.johnrengelman.shadow") || gradle.HasPlugin("com.gradleup.shadow"))
+
+	var task, artifact string
+	switch {
+	case fw.Name == FrameworkSpringBoot:
+		task, artifact = "bootJar", "build/libs/*.jar"
+	case fw.Name == FrameworkQuarkus:
+		task, artifact = "quarkusBuild", "build/quarkus-app"
+	case hasShadow:
+		task, artifact = "shadowJar", "build/libs/*-all.jar"
+	default:
+		task, artifact = "build -x test", "build/libs/*.jar"
+	}
+
+	return strings.Join([]string{gradlew, task, "--no-daemon"}, " "), artifact
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range append(append([]string{}, detectFiles...), "settings.gradle", "settings.gradle.kts", ".java-version", ".tool-versions") {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
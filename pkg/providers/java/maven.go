@@ -0,0 +1,126 @@
+package java
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+)
+
+// POM represents the parts of pom.xml that Coolpack cares about
+type POM struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Packaging  string `xml:"packaging"`
+	Parent     struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	Properties           Properties   `xml:"properties"`
+	Dependencies         []Dependency `xml:"dependencies>dependency"`
+	DependencyManagement struct {
+		Dependencies []Dependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+	Build struct {
+		Plugins []Dependency `xml:"plugins>plugin"`
+	} `xml:"build"`
+	Modules []string `xml:"modules>module"`
+}
+
+// Dependency is a Maven dependency or plugin coordinate
+type Dependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+// Properties holds the <properties> section as a map
+type Properties map[string]string
+
+// UnmarshalXML reads arbitrary child elements into the map
+func (p *Properties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(Properties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// propertyRefRegex matches ${property} references
+var propertyRefRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// ParsePOM parses pom.xml from bytes
+func ParsePOM(data []byte) (*POM, error) {
+	var pom POM
+	if err := xml.Unmarshal(data, &pom); err != nil {
+		return nil, err
+	}
+	return &pom, nil
+}
+
+// Property returns a property value with ${...} references resolved
+func (p *POM) Property(name string) string {
+	return p.resolve(p.Properties[name], 0)
+}
+
+// resolve expands ${...} references from the properties section
+func (p *POM) resolve(value string, depth int) string {
+	if depth > 5 {
+		return value
+	}
+	return propertyRefRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		name := propertyRefRegex.FindStringSubmatch(ref)[1]
+		if v, ok := p.Properties[name]; ok {
+			return p.resolve(v, depth+1)
+		}
+		return ref
+	})
+}
+
+// HasGroup checks if any dependency, managed dependency (BOM) or plugin belongs to a group
+func (p *POM) HasGroup(groupID string) bool {
+	all := append(append(append([]Dependency{}, p.Dependencies...), p.DependencyManagement.Dependencies...), p.Build.Plugins...)
+	for _, dep := range all {
+		if dep.GroupID == groupID || strings.HasPrefix(dep.GroupID, groupID+".") {
+			return true
+		}
+	}
+	return p.Parent.GroupID == groupID || strings.HasPrefix(p.Parent.GroupID, groupID+".")
+}
+
+// HasArtifact checks if a dependency or plugin with the given artifactId is declared
+func (p *POM) HasArtifact(artifactID string) bool {
+	for _, dep := range append(append([]Dependency{}, p.Dependencies...), p.Build.Plugins...) {
+		if dep.ArtifactID == artifactID {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionOf returns the resolved version of a dependency, plugin or BOM in a group
+func (p *POM) VersionOf(groupID string) string {
+	if p.Parent.GroupID == groupID {
+		return p.resolve(p.Parent.Version, 0)
+	}
+	all := append(append(append([]Dependency{}, p.DependencyManagement.Dependencies...), p.Build.Plugins...), p.Dependencies...)
+	for _, dep := range all {
+		if dep.GroupID == groupID && dep.Version != "" {
+			return p.resolve(dep.Version, 0)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package java
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultJavaVersion = "21"
+
+// majorVersionRegex extracts the Java feature release from a version ("1.8" -> 8, "17.0.2" -> 17)
+var majorVersionRegex = regexp.MustCompile(`(?:^|[^\d.])(?:1\.)?(\d+)`)
+
+// DetectJavaVersion detects the Java version to use
+// Priority:
+// 1. COOLPACK_JAVA_VERSION environment variable
+// 2. maven.compiler.release (then source/target, then java.version) in pom.xml
+// 3. Toolchain (or sourceCompatibility) in build.gradle(.kts)
+// 4. .java-version file
+// 5. .tool-versions file (asdf)
+// 6. Default to 21
+func DetectJavaVersion(ctx *app.Context, pom *POM, gradle *GradleBuild) string {
+	// 1. Check COOLPACK_JAVA_VERSION env var
+	if v := ctx.Env["COOLPACK_JAVA_VERSION"]; v != "" {
+		return strings.TrimSpace(v)
+	}
+
+	// 2. Check pom.xml properties
+	if pom != nil {
+		for _, prop := range []string{"maven.compiler.release", "maven.compiler.source", "maven.compiler.target", "java.version"} {
+			if v := normalizeJavaVersion(pom.Property(prop)); v != "" {
+				return v
+			}
+		}
+	}
+
+	// 3. Check Gradle toolchain
+	if gradle != nil {
+		if v := normalizeJavaVersion(gradle.ToolchainVersion); v != "" {
+			return v
+		}
+		if v := normalizeJavaVersion(gradle.SourceCompatibility); v != "" {
+			return v
+		}
+	}
+
+	// 4. Check .java-version file (jenv)
+	if ctx.HasFile(".java-version") {
+		if data, err := ctx.ReadFile(".java-version"); err == nil {
+			if v := normalizeJavaVersion(strings.TrimSpace(string(data))); v != "" {
+				return v
+			}
+		}
+	}
+
+	// 5. Check .tool-versions file (asdf format, e.g. "java temurin-21.0.2+13.0.LTS")
+	if v := normalizeJavaVersion(ctx.ReadToolVersion("java")); v != "" {
+		return v
+	}
+
+	// 6. Default
+	return DefaultJavaVersion
+}
+
+// normalizeJavaVersion returns the feature release of a Java version string
+// Examples: "17" -> "17", "1.8" -> "8", "21.0.2" -> "21", "temurin-17.0.9+9" -> "17"
+func normalizeJavaVersion(v string) string {
+	if v == "" || strings.Contains(v, "${") {
+		return ""
+	}
+	matches := majorVersionRegex.FindStringSubmatch(v)
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
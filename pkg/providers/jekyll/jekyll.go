@@ -0,0 +1,121 @@
+package jekyll
+
+import (
+	"fmt"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/ruby"
+)
+
+// configFiles are the Jekyll site configuration files
+var configFiles = []string{"_config.yml", "_config.yaml", "_config.toml"}
+
+// Provider is the Jekyll provider implementation
+type Provider struct{}
+
+// New creates a new Jekyll provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "jekyll"
+}
+
+// Detect checks if the application is a Jekyll site: a site config plus a
+// Gemfile depending on jekyll (or github-pages, which pins it)
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	configFile := findConfigFile(ctx)
+	if configFile == "" {
+		return app.NoMatch(), nil
+	}
+	data, err := ctx.ReadFile("Gemfile")
+	if err != nil {
+		return app.NoMatch(), nil
+	}
+	gemfile := ruby.ParseGemfile(data)
+	for _, gem := range []string{"jekyll", "github-pages"} {
+		if gemfile.Gems[gem] {
+			return app.Detected(app.ConfidenceSpecific, "found "+configFile, "Gemfile depends on "+gem), nil
+		}
+	}
+	return app.NoMatch(), nil
+}
+
+// Plan generates a build plan for the Jekyll site
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	data, err := ctx.ReadFile("Gemfile")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Gemfile: %w", err)
+	}
+	gemfile := ruby.ParseGemfile(data)
+
+	var lock *ruby.GemfileLock
+	if data, err := ctx.ReadFile("Gemfile.lock"); err == nil {
+		lock = ruby.ParseGemfileLock(data)
+	}
+
+	plan := &app.Plan{
+		Provider:        "jekyll",
+		Language:        "ruby",
+		LanguageVersion: ruby.DetectRubyVersion(ctx, gemfile, lock),
+		Framework:       "jekyll",
+		PackageManager:  "bundler",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+	if lock != nil {
+		// Bundler installs the Jekyll release locked in Gemfile.lock
+		plan.FrameworkVersion = lock.Specs["jekyll"]
+		plan.PackageManagerVersion = lock.BundledWith
+	}
+
+	plan.InstallCommand = "bundle install"
+	plan.BuildCommand = "JEKYLL_ENV=production bundle exec jekyll build"
+
+	// Native gems (sass-embedded, nokogiri) only need their build packages,
+	// the runner just serves the generated files
+	nativeGems := ruby.DetectNativeGems(gemfile, lock)
+	buildPackages, _ := ruby.GetRequiredAptPackages(nativeGems)
+	plan.Packages.Apt = buildPackages
+	if len(nativeGems) > 0 {
+		var detected []string
+		for _, gem := range nativeGems {
+			detected = append(detected, gem.Gem)
+		}
+		plan.Packages.Native = detected
+	}
+
+	// Jekyll sites are generated into _site/ and served as static files
+	plan.Runtime.OutputType = "static"
+	plan.Static.OutputDir = "_site"
+	plan.SetSource("static.output_dir", "jekyll default")
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// findConfigFile returns the site configuration file, or "" if there is none
+func findConfigFile(ctx *app.Context) string {
+	for _, f := range configFiles {
+		if ctx.HasFile(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	files := []string{findConfigFile(ctx)}
+	for _, f := range []string{"Gemfile", "Gemfile.lock", ".ruby-version", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
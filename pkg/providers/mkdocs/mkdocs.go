@@ -0,0 +1,167 @@
+package mkdocs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/python"
+)
+
+// configFiles are the MkDocs configuration files
+var configFiles = []string{"mkdocs.yml", "mkdocs.yaml"}
+
+// themeNameRegex matches a theme name, inline (theme: material) or in the theme block (name: material)
+var themeNameRegex = regexp.MustCompile(`^(?:theme|\s+name):\s*["']?([\w-]+)`)
+
+// DefaultMkDocsVersion is the MkDocs release installed for sites that don't declare one
+const DefaultMkDocsVersion = "1.6.1"
+
+// themePackages maps third-party themes to the pinned package that provides them
+var themePackages = map[string]string{
+	"material": "mkdocs-material==9.6.14",
+	"windmill": "mkdocs-windmill==1.0.5",
+	"dracula":  "mkdocs-dracula-theme==1.0.7",
+}
+
+// Provider is the MkDocs provider implementation
+type Provider struct{}
+
+// New creates a new MkDocs provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "mkdocs"
+}
+
+// Detect checks if the application is an MkDocs site
+// Python web apps keep their documentation next to the code, so mkdocs.yml
+// is only a weak match when there's a web framework to serve
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	configFile := findConfigFile(ctx)
+	if configFile == "" {
+		return app.NoMatch(), nil
+	}
+	project, err := python.LoadProject(ctx)
+	if err != nil {
+		return app.Detected(app.ConfidenceSpecific, "found "+configFile), nil
+	}
+	if fw := python.DetectFramework(ctx, project); fw.Name != python.FrameworkNone {
+		return app.Detected(app.ConfidenceWeak, "found "+configFile, fmt.Sprintf("project is a %s app, docs are likely secondary", fw.Name)), nil
+	}
+	return app.Detected(app.ConfidenceSpecific, "found "+configFile), nil
+}
+
+// Plan generates a build plan for the MkDocs site
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	configFile := findConfigFile(ctx)
+	project, err := python.LoadProject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Python project files: %w", err)
+	}
+	pmInfo := python.DetectPackageManager(ctx, project)
+	pythonVersion := python.DetectPythonVersion(ctx, project)
+
+	plan := &app.Plan{
+		Provider:        "mkdocs",
+		Language:        "python",
+		LanguageVersion: pythonVersion.Version,
+		Framework:       "mkdocs",
+		DetectedFiles:   detectRelevantFiles(ctx, configFile),
+	}
+	plan.SetSource("language_version", pythonVersion.Source)
+
+	// Install from the project's pinned dependencies when it declares MkDocs,
+	// then from docs/requirements.txt (the Read the Docs layout), otherwise
+	// install MkDocs and the configured theme
+	switch {
+	case project.HasDependency("mkdocs") || project.HasDependency("mkdocs-material"):
+		plan.PackageManager = string(pmInfo.Name)
+		plan.PackageManagerVersion = pmInfo.Version
+		plan.InstallCommand = pmInfo.GetInstallCommand()
+		plan.FrameworkVersion = python.CleanVersion(project.GetDependencyVersion("mkdocs"))
+		if files := pmInfo.GetDependencyFiles(); len(files) > 0 {
+			plan.Python.DependencyFiles = files
+		}
+	case ctx.HasFile("docs/requirements.txt"):
+		plan.PackageManager = string(python.PackageManagerPip)
+		plan.InstallCommand = "pip install -r docs/requirements.txt"
+		plan.Python.DependencyFiles = []string{"docs/requirements.txt"}
+	default:
+		version := DefaultMkDocsVersion
+		if v := ctx.Env["COOLPACK_MKDOCS_VERSION"]; v != "" {
+			version = strings.TrimPrefix(v, "v")
+		}
+		packages := []string{"mkdocs==" + version}
+		if pkg := themePackages[detectTheme(ctx, configFile)]; pkg != "" {
+			packages = append(packages, pkg)
+		}
+		plan.FrameworkVersion = version
+		plan.PackageManager = string(python.PackageManagerPip)
+		plan.InstallCommand = "pip install " + strings.Join(packages, " ")
+	}
+
+	plan.BuildCommand = "mkdocs build"
+
+	// MkDocs sites are generated into site/ and served as static files
+	plan.Runtime.OutputType = "static"
+	plan.Static.OutputDir = "site"
+	plan.SetSource("static.output_dir", "mkdocs default")
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// findConfigFile returns the MkDocs configuration file, or "" if there is none
+func findConfigFile(ctx *app.Context) string {
+	for _, f := range configFiles {
+		if ctx.HasFile(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// detectTheme returns the theme name from the MkDocs configuration
+func detectTheme(ctx *app.Context, configFile string) string {
+	data, err := ctx.ReadFile(configFile)
+	if err != nil {
+		return ""
+	}
+
+	inTheme := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "theme:") {
+			inTheme = true
+		} else if line != "" && line[0] != ' ' && line[0] != '#' {
+			// Any other top-level key ends the theme block
+			inTheme = false
+		}
+		if !inTheme {
+			continue
+		}
+		if matches := themeNameRegex.FindStringSubmatch(line); len(matches) > 1 {
+			return matches[1]
+		}
+	}
+	return ""
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context, configFile string) []string {
+	files := []string{configFile}
+	for _, f := range []string{"requirements.txt", "docs/requirements.txt", "pyproject.toml", "poetry.lock", "uv.lock", "Pipfile", "Pipfile.lock", ".python-version"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -43,6 +43,10 @@ type FrameworkInfo struct {
 	Name       Framework
 	Version    string
 	OutputType OutputType
+	// Source is the dependency or file the framework was detected from
+	Source string
+	// OutputSource is the config that set the output type, empty for the framework default
+	OutputSource string
 }
 
 // DetectFramework detects the framework used by the project
@@ -60,10 +64,12 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	// Meta-frameworks with SSR (check these first as they're more specific)
 	if pkg.HasDependency("next") {
 		info.Name = FrameworkNextJS
+		info.Source = frameworkSource(ctx, pkg, []string{"next"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("next"))
 		// Check if it's a static export (output: 'export' in next.config.*)
 		if isNextJSStaticExport(ctx) {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "next.config output: 'export'"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -73,16 +79,19 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	if pkg.HasDependency("@remix-run/react") || pkg.HasDependency("@remix-run/node") {
 		info.Name = FrameworkRemix
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@remix-run/react"))
+		info.Source = frameworkSource(ctx, pkg, []string{"@remix-run/react", "@remix-run/node"})
 		info.OutputType = OutputTypeServer
 		return info
 	}
 
 	if pkg.HasDependency("nuxt") || pkg.HasDependency("nuxt3") {
 		info.Name = FrameworkNuxt
+		info.Source = frameworkSource(ctx, pkg, []string{"nuxt", "nuxt3"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("nuxt"))
 		// Check for ssr: false in nuxt.config.*
 		if isNuxtSPAMode(ctx) {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "nuxt.config ssr: false"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -91,10 +100,12 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("astro") || ctx.HasFile("astro.config.mjs") || ctx.HasFile("astro.config.js") || ctx.HasFile("astro.config.ts") {
 		info.Name = FrameworkAstro
+		info.Source = frameworkSource(ctx, pkg, []string{"astro"}, "astro.config.mjs", "astro.config.js", "astro.config.ts")
 		info.Version = cleanVersion(pkg.GetDependencyVersion("astro"))
 		// Astro is static by default, SSR requires output: 'server' or 'hybrid'
 		if isAstroSSRMode(ctx) {
 			info.OutputType = OutputTypeServer
+			info.OutputSource = "astro.config output: server or hybrid"
 		} else {
 			info.OutputType = OutputTypeStatic
 		}
@@ -103,10 +114,12 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("@sveltejs/kit") {
 		info.Name = FrameworkSvelteKit
+		info.Source = frameworkSource(ctx, pkg, []string{"@sveltejs/kit"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@sveltejs/kit"))
 		// Check if using static adapter
 		if pkg.HasDependency("@sveltejs/adapter-static") {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "package.json dependency @sveltejs/adapter-static"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -115,6 +128,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("solid-start") || pkg.HasDependency("@solidjs/start") {
 		info.Name = FrameworkSolidStart
+		info.Source = frameworkSource(ctx, pkg, []string{"@solidjs/start", "solid-start"})
 		// Try @solidjs/start first (newer), then solid-start (older)
 		version := pkg.GetDependencyVersion("@solidjs/start")
 		if version == "" {
@@ -124,6 +138,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 		// Check for ssr: false in app.config.*
 		if isSolidStartSPAMode(ctx) {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "app.config ssr: false"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -132,10 +147,12 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("@tanstack/start") || pkg.HasDependency("@tanstack/react-start") {
 		info.Name = FrameworkTanStack
+		info.Source = frameworkSource(ctx, pkg, []string{"@tanstack/start", "@tanstack/react-start"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@tanstack/start"))
 		// Check for server.preset: 'static' in app.config.*
 		if isTanStackStartStaticMode(ctx) {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "app.config server.preset: 'static'"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -146,9 +163,11 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	if pkg.HasDependency("react-router") && (ctx.HasFile("react-router.config.ts") || ctx.HasFile("react-router.config.js")) {
 		info.Name = FrameworkRemix
 		info.Version = cleanVersion(pkg.GetDependencyVersion("react-router"))
+		info.Source = frameworkSource(ctx, pkg, nil, "react-router.config.ts", "react-router.config.js")
 		// Check for ssr: false in react-router.config.*
 		if isReactRouterSPAMode(ctx) {
 			info.OutputType = OutputTypeStatic
+			info.OutputSource = "react-router.config ssr: false"
 		} else {
 			info.OutputType = OutputTypeServer
 		}
@@ -157,6 +176,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("gatsby") {
 		info.Name = FrameworkGatsby
+		info.Source = frameworkSource(ctx, pkg, []string{"gatsby"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("gatsby"))
 		info.OutputType = OutputTypeStatic
 		return info
@@ -164,6 +184,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("@11ty/eleventy") {
 		info.Name = FrameworkEleventy
+		info.Source = frameworkSource(ctx, pkg, []string{"@11ty/eleventy"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@11ty/eleventy"))
 		info.OutputType = OutputTypeStatic
 		return info
@@ -172,10 +193,12 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	// Angular detection (check before backend frameworks since Angular SSR uses Express)
 	if pkg.HasDependency("@angular/core") || ctx.HasFile("angular.json") {
 		info.Name = FrameworkAngular
+		info.Source = frameworkSource(ctx, pkg, []string{"@angular/core"}, "angular.json")
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@angular/core"))
 		// Check for @angular/ssr for SSR mode
 		if pkg.HasDependency("@angular/ssr") {
 			info.OutputType = OutputTypeServer
+			info.OutputSource = "package.json dependency @angular/ssr"
 		} else {
 			info.OutputType = OutputTypeStatic
 		}
@@ -185,6 +208,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	// Backend frameworks (need Node.js server at runtime)
 	if pkg.HasDependency("@adonisjs/core") {
 		info.Name = FrameworkAdonisJS
+		info.Source = frameworkSource(ctx, pkg, []string{"@adonisjs/core"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@adonisjs/core"))
 		info.OutputType = OutputTypeServer
 		return info
@@ -192,6 +216,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("@nestjs/core") {
 		info.Name = FrameworkNestJS
+		info.Source = frameworkSource(ctx, pkg, []string{"@nestjs/core"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("@nestjs/core"))
 		info.OutputType = OutputTypeServer
 		return info
@@ -199,6 +224,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("fastify") {
 		info.Name = FrameworkFastify
+		info.Source = frameworkSource(ctx, pkg, []string{"fastify"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("fastify"))
 		info.OutputType = OutputTypeServer
 		return info
@@ -206,6 +232,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 
 	if pkg.HasDependency("express") {
 		info.Name = FrameworkExpress
+		info.Source = frameworkSource(ctx, pkg, []string{"express"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("express"))
 		info.OutputType = OutputTypeServer
 		return info
@@ -214,6 +241,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	// Check for Create React App
 	if pkg.HasDependency("react-scripts") {
 		info.Name = FrameworkCRA
+		info.Source = frameworkSource(ctx, pkg, []string{"react-scripts"})
 		info.Version = cleanVersion(pkg.GetDependencyVersion("react-scripts"))
 		info.OutputType = OutputTypeStatic
 		return info
@@ -223,6 +251,7 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	// Vite always produces static output
 	if pkg.HasDependency("vite") || ctx.HasFile("vite.config.js") || ctx.HasFile("vite.config.ts") || ctx.HasFile("vite.config.mjs") {
 		info.Name = FrameworkVite
+		info.Source = frameworkSource(ctx, pkg, []string{"vite"}, "vite.config.js", "vite.config.ts", "vite.config.mjs")
 		info.Version = cleanVersion(pkg.GetDependencyVersion("vite"))
 		info.OutputType = OutputTypeStatic
 		return info
@@ -231,6 +260,21 @@ func DetectFramework(ctx *app.Context, pkg *PackageJSON) FrameworkInfo {
 	return info
 }
 
+// frameworkSource describes the first of the dependencies or files that was found
+func frameworkSource(ctx *app.Context, pkg *PackageJSON, deps []string, files ...string) string {
+	for _, dep := range deps {
+		if pkg.HasDependency(dep) {
+			return "package.json dependency " + dep
+		}
+	}
+	for _, f := range files {
+		if ctx.HasFile(f) {
+			return f
+		}
+	}
+	return ""
+}
+
 // isSPAProject checks if the project appears to be a Single Page Application
 func isSPAProject(pkg *PackageJSON) bool {
 	spaIndicators := []string{
@@ -503,6 +547,25 @@ func (f FrameworkInfo) GetDefaultBuildCommand(pm PackageManagerInfo) string {
 	}
 }
 
+// GetDefaultOutputDir returns the directory a framework writes static output to
+func (f FrameworkInfo) GetDefaultOutputDir() string {
+	switch f.Name {
+	case FrameworkNextJS:
+		return "out"
+	case FrameworkNuxt, FrameworkSolidStart, FrameworkTanStack:
+		return ".output/public"
+	case FrameworkGatsby:
+		return "public"
+	case FrameworkSvelteKit:
+		return "build"
+	case FrameworkEleventy:
+		return "_site"
+	default:
+		// Vite, Create React App, Angular and Astro
+		return "dist"
+	}
+}
+
 // GetDefaultStartCommand returns the default start command for a framework
 func (f FrameworkInfo) GetDefaultStartCommand(pm PackageManagerInfo) string {
 	run := pm.GetRunCommand()
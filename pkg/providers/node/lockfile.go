@@ -0,0 +1,357 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/deno"
+)
+
+// Lockfile represents the resolved dependency tree of a lock file
+type Lockfile struct {
+	// File is the lock file that was read
+	File string
+
+	// Packages maps every resolved package (direct and transitive) to its details,
+	// with the versions of a package merged
+	Packages map[string]*LockedPackage
+}
+
+// LockedPackage is a package resolved in a lock file
+type LockedPackage struct {
+	// Versions are the resolved versions of the package
+	Versions []string
+
+	// Dependencies are the names of the packages it depends on (including optional ones)
+	Dependencies []string
+
+	// InstallScript is set when the package runs an install script or builds with node-gyp
+	// (npm hasInstallScript/gypfile, pnpm requiresBuild, bun trustedDependencies)
+	InstallScript bool
+}
+
+// LoadLockfile reads the lock file of the package manager
+// Returns nil if there is none or it can't be parsed (bun.lockb is binary)
+func LoadLockfile(ctx *app.Context, pm PackageManagerInfo) *Lockfile {
+	var files []string
+	var parse func([]byte) (*Lockfile, error)
+	switch pm.Name {
+	case PackageManagerPNPM:
+		files, parse = []string{"pnpm-lock.yaml"}, ParsePnpmLock
+	case PackageManagerYarnBerry, PackageManagerYarn1:
+		files, parse = []string{"yarn.lock"}, ParseYarnLock
+	case PackageManagerBun:
+		files, parse = []string{"bun.lock"}, ParseBunLock
+	default:
+		files, parse = []string{"npm-shrinkwrap.json", "package-lock.json"}, ParseNpmLock
+	}
+
+	for _, file := range files {
+		data, err := ctx.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		lock, err := parse(data)
+		if err != nil {
+			return nil
+		}
+		lock.File = file
+		return lock
+	}
+	return nil
+}
+
+// ParseNpmLock parses package-lock.json or npm-shrinkwrap.json from bytes
+// Version 2 and 3 list every package under "packages", version 1 nests them under "dependencies"
+func ParseNpmLock(data []byte) (*Lockfile, error) {
+	var raw struct {
+		Packages map[string]struct {
+			Name                 string            `json:"name"`
+			Version              string            `json:"version"`
+			Dependencies         map[string]string `json:"dependencies"`
+			OptionalDependencies map[string]string `json:"optionalDependencies"`
+			HasInstallScript     bool              `json:"hasInstallScript"`
+			Gypfile              bool              `json:"gypfile"`
+		} `json:"packages"`
+		Dependencies map[string]npmLockDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	lock := newLockfile()
+	for key, entry := range raw.Packages {
+		// Installed packages are keyed by their node_modules path, workspace packages by their directory
+		name := entry.Name
+		if i := strings.LastIndex(key, "node_modules/"); i >= 0 {
+			name = key[i+len("node_modules/"):]
+		}
+		if name == "" || key == "" {
+			continue
+		}
+		pkg := lock.add(name, entry.Version, sortedKeys(entry.Dependencies, entry.OptionalDependencies)...)
+		pkg.InstallScript = pkg.InstallScript || entry.HasInstallScript || entry.Gypfile
+	}
+
+	if len(raw.Packages) == 0 {
+		addNpmLockDependencies(lock, raw.Dependencies)
+	}
+	return lock, nil
+}
+
+// npmLockDependency is a package of a version 1 package-lock.json
+type npmLockDependency struct {
+	Version      string                       `json:"version"`
+	Requires     map[string]string            `json:"requires"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+// addNpmLockDependencies adds a version 1 dependency tree to the lock file
+func addNpmLockDependencies(lock *Lockfile, deps map[string]npmLockDependency) {
+	for name, dep := range deps {
+		lock.add(name, dep.Version, sortedKeys(dep.Requires)...)
+		addNpmLockDependencies(lock, dep.Dependencies)
+	}
+}
+
+// ParsePnpmLock parses pnpm-lock.yaml from bytes
+// Packages are read from "packages" and, since lockfile version 9, "snapshots"
+func ParsePnpmLock(data []byte) (*Lockfile, error) {
+	lock := newLockfile()
+
+	section := ""
+	var pkg *LockedPackage
+	inDependencies := false
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			section = strings.TrimSuffix(trimmed, ":")
+			pkg = nil
+		case section != "packages" && section != "snapshots":
+			continue
+		case indent == 2 && strings.HasSuffix(trimmed, ":"):
+			pkg = lock.add(pnpmPackage(strings.TrimSuffix(trimmed, ":")))
+			inDependencies = false
+		case pkg == nil:
+			continue
+		case indent == 4:
+			key, value, _ := strings.Cut(trimmed, ":")
+			inDependencies = key == "dependencies" || key == "optionalDependencies"
+			if key == "requiresBuild" && strings.TrimSpace(value) == "true" {
+				pkg.InstallScript = true
+			}
+		case indent == 6 && inDependencies:
+			if name, _, ok := strings.Cut(trimmed, ":"); ok {
+				pkg.Dependencies = append(pkg.Dependencies, unquote(name))
+			}
+		}
+	}
+
+	return lock, scanner.Err()
+}
+
+// pnpmPackage returns the package name and version of a pnpm-lock.yaml package key:
+// /name/1.0.0_peer@1.0.0 (version 5), /name@1.0.0(peer@1.0.0) (version 6) or name@1.0.0 (version 9)
+func pnpmPackage(key string) (string, string) {
+	key = strings.TrimPrefix(unquote(key), "/")
+	key, _, _ = strings.Cut(key, "(")
+
+	// Scoped names have a slash of their own
+	segments := 1
+	if strings.HasPrefix(key, "@") {
+		segments = 2
+	}
+	parts := strings.SplitN(key, "/", segments+1)
+	if len(parts) > segments {
+		version, _, _ := strings.Cut(parts[segments], "_")
+		return strings.Join(parts[:segments], "/"), version
+	}
+	return lockPackage(key)
+}
+
+// ParseYarnLock parses yarn.lock from bytes, in the Yarn 1 and the Yarn Berry format
+func ParseYarnLock(data []byte) (*Lockfile, error) {
+	lock := newLockfile()
+
+	var pkg *LockedPackage
+	inDependencies := false
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			// "name@^1.0.0", "name@^1.1.0": (Yarn 1) or "name@npm:^1.0.0, name@npm:^1.1.0": (Berry)
+			pkg = nil
+			if header := strings.TrimSuffix(trimmed, ":"); header != "__metadata" {
+				descriptor, _, _ := strings.Cut(header, ",")
+				name, _ := lockPackage(unquote(descriptor))
+				pkg = lock.add(name, "")
+			}
+		case pkg == nil:
+			continue
+		case indent == 2:
+			key, value, _ := strings.Cut(trimmed, ":")
+			if strings.HasPrefix(trimmed, "version ") {
+				// Yarn 1 separates keys from values with a space
+				key, value, _ = strings.Cut(trimmed, " ")
+			}
+			inDependencies = key == "dependencies" || key == "optionalDependencies"
+			if key == "version" {
+				pkg.Versions = append(pkg.Versions, unquote(strings.TrimSpace(value)))
+			}
+		case indent == 4 && inDependencies:
+			// name "^1.0.0" (Yarn 1) or name: "npm:^1.0.0" (Berry), either may be quoted
+			name := trimmed
+			if strings.HasPrefix(name, `"`) {
+				if end := strings.Index(name[1:], `"`); end >= 0 {
+					name = name[1 : end+1]
+				}
+			} else if end := strings.IndexAny(name, " :"); end >= 0 {
+				name = name[:end]
+			}
+			pkg.Dependencies = append(pkg.Dependencies, name)
+		}
+	}
+
+	return lock, scanner.Err()
+}
+
+// ParseBunLock parses bun.lock (JSONC) from bytes
+// Packages map to arrays of "name@version", the registry URL, their metadata and integrity
+func ParseBunLock(data []byte) (*Lockfile, error) {
+	var raw struct {
+		Workspaces map[string]bunLockMetadata   `json:"workspaces"`
+		Packages   map[string][]json.RawMessage `json:"packages"`
+		Trusted    []string                     `json:"trustedDependencies"`
+	}
+	if err := json.Unmarshal(deno.StripJSONC(data), &raw); err != nil {
+		return nil, err
+	}
+
+	lock := newLockfile()
+	for _, workspace := range raw.Workspaces {
+		if workspace.Name != "" {
+			lock.add(workspace.Name, "", sortedKeys(workspace.Dependencies, workspace.OptionalDependencies)...)
+		}
+	}
+	for _, entry := range raw.Packages {
+		var ident string
+		if len(entry) == 0 || json.Unmarshal(entry[0], &ident) != nil {
+			continue
+		}
+		pkg := lock.add(lockPackage(ident))
+		for _, field := range entry[1:] {
+			var metadata bunLockMetadata
+			if json.Unmarshal(field, &metadata) == nil {
+				pkg.Dependencies = append(pkg.Dependencies, sortedKeys(metadata.Dependencies, metadata.OptionalDependencies)...)
+			}
+		}
+	}
+	// Bun only runs the install scripts of trusted dependencies
+	for _, name := range raw.Trusted {
+		lock.add(name, "").InstallScript = true
+	}
+	return lock, nil
+}
+
+// bunLockMetadata is the metadata of a bun.lock workspace or package
+type bunLockMetadata struct {
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// newLockfile returns an empty lock file
+func newLockfile() *Lockfile {
+	return &Lockfile{Packages: make(map[string]*LockedPackage)}
+}
+
+// add records a package version and its dependencies, merging them with other versions of the package
+func (l *Lockfile) add(name, version string, dependencies ...string) *LockedPackage {
+	pkg, ok := l.Packages[name]
+	if !ok {
+		pkg = &LockedPackage{}
+		l.Packages[name] = pkg
+	}
+	if version != "" {
+		pkg.Versions = append(pkg.Versions, version)
+	}
+	pkg.Dependencies = append(pkg.Dependencies, dependencies...)
+	return pkg
+}
+
+// versions returns the resolved versions of a package, nil if it isn't in the lock file
+func (p *LockedPackage) versions() []string {
+	if p == nil {
+		return nil
+	}
+	return p.Versions
+}
+
+// PulledInBy maps every package reachable from the top-level dependencies to the
+// first top-level dependency that pulls it in. Top-level dependencies map to themselves
+func (l *Lockfile) PulledInBy(roots []string) map[string]string {
+	via := make(map[string]string)
+	for _, root := range roots {
+		via[root] = root
+	}
+
+	for _, root := range roots {
+		var queue []string
+		if pkg, ok := l.Packages[root]; ok {
+			queue = append(queue, pkg.Dependencies...)
+		}
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			if _, seen := via[name]; seen {
+				continue
+			}
+			via[name] = root
+			if pkg, ok := l.Packages[name]; ok {
+				queue = append(queue, pkg.Dependencies...)
+			}
+		}
+	}
+	return via
+}
+
+// lockPackage splits a descriptor (name@1.0.0, @scope/name@npm:^1.0.0) into the package name and version
+func lockPackage(descriptor string) (string, string) {
+	if i := strings.Index(descriptor[min(1, len(descriptor)):], "@"); i >= 0 {
+		return descriptor[:i+1], descriptor[i+2:]
+	}
+	return descriptor, ""
+}
+
+// unquote removes the YAML quotes around a key
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
+
+// sortedKeys returns the keys of the maps in sorted order
+func sortedKeys(maps ...map[string]string) []string {
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,77 +1,95 @@
 package node
 
+import (
+	"fmt"
+	"sort"
+)
+
 // NativeDependency represents a Node.js package that requires native system dependencies
+// Registry files use the JSON names
 type NativeDependency struct {
 	// Package is the npm package name
-	Package string
+	Package string `json:"package"`
+	// Versions is the semver range of the package versions the entry applies to, empty for all
+	Versions string `json:"versions,omitempty"`
 	// AptPackages are the Debian/Ubuntu packages needed for building
-	AptPackages []string
+	AptPackages []string `json:"build,omitempty"`
+	// RuntimePackages are the Debian/Ubuntu packages needed at runtime
+	RuntimePackages []string `json:"runtime,omitempty"`
 	// Description explains why these packages are needed
-	Description string
+	Description string `json:"description,omitempty"`
+	// GlibcOnly is set for packages that don't run on musl (Alpine)
+	GlibcOnly bool `json:"glibc_only,omitempty"`
+	// Source is the registry file the entry came from, empty for built-ins
+	Source string `json:"-"`
+	// Via is the top-level dependency that pulls the package in, empty for direct dependencies
+	Via string `json:"-"`
+}
+
+// String returns the package name and, for transitive dependencies, what pulls it in
+func (d NativeDependency) String() string {
+	if d.Via != "" {
+		return fmt.Sprintf("%s (via %s)", d.Package, d.Via)
+	}
+	return d.Package
+}
+
+// browserLibraries are the shared libraries a headless browser loads
+var browserLibraries = []string{
+	"libnss3",
+	"libatk1.0-0",
+	"libatk-bridge2.0-0",
+	"libcups2",
+	"libdrm2",
+	"libxkbcommon0",
+	"libxcomposite1",
+	"libxdamage1",
+	"libxfixes3",
+	"libxrandr2",
+	"libgbm1",
+	"libasound2",
+	"libpango-1.0-0",
+	"libcairo2",
 }
 
 // NativeDependencies is a list of known packages requiring native dependencies
 var NativeDependencies = []NativeDependency{
 	{
-		Package:     "sharp",
-		AptPackages: []string{"libvips-dev"},
-		Description: "Image processing library",
+		Package:         "sharp",
+		AptPackages:     []string{"libvips-dev"},
+		RuntimePackages: []string{"libvips42"},
+		Description:     "Image processing library",
 	},
 	{
-		Package:     "@prisma/client",
-		AptPackages: []string{"openssl"},
-		Description: "Database ORM",
+		Package:         "@prisma/client",
+		AptPackages:     []string{"openssl"},
+		RuntimePackages: []string{"openssl"},
+		Description:     "Database ORM",
 	},
 	{
-		Package:     "prisma",
-		AptPackages: []string{"openssl"},
-		Description: "Database ORM CLI",
+		Package:         "prisma",
+		AptPackages:     []string{"openssl"},
+		RuntimePackages: []string{"openssl"},
+		Description:     "Database ORM CLI",
 	},
 	{
-		Package:     "puppeteer",
-		AptPackages: []string{
-			"chromium",
-			"libnss3",
-			"libatk1.0-0",
-			"libatk-bridge2.0-0",
-			"libcups2",
-			"libdrm2",
-			"libxkbcommon0",
-			"libxcomposite1",
-			"libxdamage1",
-			"libxfixes3",
-			"libxrandr2",
-			"libgbm1",
-			"libasound2",
-			"libpango-1.0-0",
-			"libcairo2",
-		},
-		Description: "Headless Chrome automation",
+		Package:         "puppeteer",
+		AptPackages:     append([]string{"chromium"}, browserLibraries...),
+		RuntimePackages: append([]string{"chromium"}, browserLibraries...),
+		Description:     "Headless Chrome automation",
 	},
 	{
-		Package:     "playwright",
-		AptPackages: []string{
-			"libnss3",
-			"libatk1.0-0",
-			"libatk-bridge2.0-0",
-			"libcups2",
-			"libdrm2",
-			"libxkbcommon0",
-			"libxcomposite1",
-			"libxdamage1",
-			"libxfixes3",
-			"libxrandr2",
-			"libgbm1",
-			"libasound2",
-			"libpango-1.0-0",
-			"libcairo2",
-		},
-		Description: "Browser automation",
+		Package:         "playwright",
+		AptPackages:     browserLibraries,
+		RuntimePackages: browserLibraries,
+		Description:     "Browser automation",
+		GlibcOnly:       true,
 	},
 	{
-		Package:     "canvas",
-		AptPackages: []string{"libcairo2-dev", "libjpeg-dev", "libpango1.0-dev", "libgif-dev", "librsvg2-dev"},
-		Description: "Canvas rendering",
+		Package:         "canvas",
+		AptPackages:     []string{"libcairo2-dev", "libjpeg-dev", "libpango1.0-dev", "libgif-dev", "librsvg2-dev"},
+		RuntimePackages: []string{"libcairo2", "libjpeg62-turbo", "libpango-1.0-0", "libpangocairo-1.0-0", "libgif7", "librsvg2-2"},
+		Description:     "Canvas rendering",
 	},
 	{
 		Package:     "bcrypt",
@@ -120,32 +138,162 @@ var NativeDependencies = []NativeDependency{
 	},
 }
 
-// DetectNativeDependencies checks which native dependencies are used by the project
-func DetectNativeDependencies(pkg *PackageJSON) []NativeDependency {
+// prebuiltInstallScripts are packages whose install scripts download prebuilt binaries
+// or only print a message, so they don't need a compiler
+var prebuiltInstallScripts = map[string]bool{
+	"esbuild":            true,
+	"@swc/core":          true,
+	"@biomejs/biome":     true,
+	"@tailwindcss/oxide": true,
+	"unrs-resolver":      true,
+	"@sentry/cli":        true,
+	"@prisma/engines":    true,
+	"core-js":            true,
+	"core-js-pure":       true,
+	"es5-ext":            true,
+	"protobufjs":         true,
+	"@nestjs/core":       true,
+	"cypress":            true,
+	"electron":           true,
+	"workerd":            true,
+	"msw":                true,
+	"husky":              true,
+	"lefthook":           true,
+	"fsevents":           true,
+	"nx":                 true,
+}
+
+// installScriptDependency is the native dependency of a package that builds at install time
+var installScriptDependency = NativeDependency{
+	AptPackages: []string{"build-essential", "python3"},
+	Description: "Install script (node-gyp)",
+}
+
+// DetectNativeDependencies checks which native dependencies of the registry are used by the project
+// The lock file, when there is one, adds the native packages anywhere in the resolved
+// tree and packages with an install script, each attributed to the top-level dependency
+// that pulls it in. Versions come from the lock file, or the package.json range without one
+func DetectNativeDependencies(registry []NativeDependency, pkg *PackageJSON, lock *Lockfile) []NativeDependency {
 	var detected []NativeDependency
+	known := make(map[string]bool)
+	var names []string
+	for _, dep := range registry {
+		if !known[dep.Package] {
+			known[dep.Package] = true
+			names = append(names, dep.Package)
+		}
+	}
 
-	for _, dep := range NativeDependencies {
-		if pkg.HasDependency(dep.Package) {
-			detected = append(detected, dep)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if pkg.HasDependency(name) {
+			seen[name] = true
+			if dep, ok := resolveNativeDependency(registry, name, dependencyVersions(pkg, lock, name)); ok {
+				detected = append(detected, dep)
+			}
 		}
 	}
+	if lock == nil {
+		return detected
+	}
+
+	// Production dependencies are preferred when both pull a package in
+	roots := append(sortedKeys(pkg.Dependencies), sortedKeys(pkg.DevDependencies)...)
+	via := lock.PulledInBy(roots)
+
+	for _, name := range names {
+		if root, ok := via[name]; ok && !seen[name] {
+			seen[name] = true
+			if dep, ok := resolveNativeDependency(registry, name, lock.Packages[name].versions()); ok {
+				dep.Via = root
+				detected = append(detected, dep)
+			}
+		}
+	}
+
+	var scripts []string
+	for name := range via {
+		if locked, ok := lock.Packages[name]; ok && locked.InstallScript && !known[name] && !prebuiltInstallScripts[name] {
+			scripts = append(scripts, name)
+		}
+	}
+	sort.Strings(scripts)
+	for _, name := range scripts {
+		dep := installScriptDependency
+		dep.Package = name
+		if via[name] != name {
+			dep.Via = via[name]
+		}
+		detected = append(detected, dep)
+	}
 
 	return detected
 }
 
-// GetRequiredAptPackages returns a deduplicated list of APT packages needed
-func GetRequiredAptPackages(deps []NativeDependency) []string {
-	seen := make(map[string]bool)
-	var packages []string
+// resolveNativeDependency returns the first registry entry of a package whose range matches
+// one of its versions. Every entry matches when the versions are unknown.
+// An entry without APT packages marks the package as not needing any
+func resolveNativeDependency(registry []NativeDependency, name string, versions []string) (NativeDependency, bool) {
+	for _, dep := range registry {
+		if dep.Package != name || !dep.matchesAny(versions) {
+			continue
+		}
+		return dep, len(dep.AptPackages) > 0 || len(dep.RuntimePackages) > 0
+	}
+	return NativeDependency{}, false
+}
+
+// matchesAny reports whether the entry's range matches one of the versions
+func (d NativeDependency) matchesAny(versions []string) bool {
+	if d.Versions == "" || len(versions) == 0 {
+		return true
+	}
+	r, err := parseRange(d.Versions)
+	if err != nil {
+		return false
+	}
+	for _, version := range versions {
+		if v, parts, err := parseSemver(version); err == nil && parts > 0 && r.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyVersions returns the resolved versions of a direct dependency, or the lowest
+// version of its package.json range (^0.32.6 -> 0.32.6) without a lock file
+func dependencyVersions(pkg *PackageJSON, lock *Lockfile, name string) []string {
+	if lock != nil {
+		if versions := lock.Packages[name].versions(); len(versions) > 0 {
+			return versions
+		}
+	}
+	if version := versionNumberRegex.FindString(pkg.GetDependencyVersion(name)); version != "" {
+		return []string{version}
+	}
+	return nil
+}
+
+// GetRequiredAptPackages returns the deduplicated APT packages needed
+// to build the native dependencies and to run them
+func GetRequiredAptPackages(deps []NativeDependency) (build []string, runtime []string) {
+	seenBuild := make(map[string]bool)
+	seenRuntime := make(map[string]bool)
 
 	for _, dep := range deps {
 		for _, pkg := range dep.AptPackages {
-			if !seen[pkg] {
-				seen[pkg] = true
-				packages = append(packages, pkg)
+			if !seenBuild[pkg] {
+				seenBuild[pkg] = true
+				build = append(build, pkg)
+			}
+		}
+		for _, pkg := range dep.RuntimePackages {
+			if !seenRuntime[pkg] {
+				seenRuntime[pkg] = true
+				runtime = append(runtime, pkg)
 			}
 		}
 	}
 
-	return packages
+	return build, runtime
 }
@@ -20,8 +20,37 @@ func (p *Provider) Name() string {
 }
 
 // Detect checks if the application is a Node.js project
-func (p *Provider) Detect(ctx *app.Context) (bool, error) {
-	return ctx.HasFile("package.json"), nil
+// A package.json that only builds static files is often the frontend of an app in
+// another language, so only a Node.js server ranks as an application
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("package.json") {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found package.json")
+
+	data, err := ctx.ReadFile("package.json")
+	if err != nil {
+		return detection, err
+	}
+	pkg, err := ParsePackageJSON(data)
+	if err != nil {
+		return detection, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	// A selected workspace app makes the monorepo a Node.js build
+	if selector := ctx.Env["COOLPACK_APP"]; selector != "" && DiscoverWorkspace(ctx, pkg) != nil {
+		detection.Raise(app.ConfidenceExplicit, "workspace app "+selector+" selected")
+		return detection, nil
+	}
+
+	fwInfo := DetectFramework(ctx, pkg)
+	switch {
+	case fwInfo.OutputType == OutputTypeServer:
+		detection.Raise(app.ConfidenceApp, fmt.Sprintf("%s runs a server", fwInfo.Name))
+	case pkg.HasScript("start"):
+		detection.Raise(app.ConfidenceApp, "has a start script")
+	}
+	return detection, nil
 }
 
 // Plan generates a build plan for the Node.js application
@@ -37,6 +66,11 @@ func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
 		return nil, fmt.Errorf("failed to parse package.json: %w", err)
 	}
 
+	// Plan a single app of a monorepo (COOLPACK_APP or --app)
+	if selector := ctx.Env["COOLPACK_APP"]; selector != "" {
+		return planWorkspaceApp(ctx, pkg, selector)
+	}
+
 	// Detect package manager
 	pmInfo := DetectPackageManager(ctx, pkg)
 
@@ -47,119 +81,183 @@ func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
 	fwInfo := DetectFramework(ctx, pkg)
 
 	// Build the plan
-	language := "nodejs"
-	languageVersion := nodeVersion
-	if pmInfo.Name == PackageManagerBun {
-		language = "bun"
-		// For bun, use bun version as language version
-		if pmInfo.Version != "" {
-			languageVersion = pmInfo.Version
-		} else {
-			languageVersion = "latest"
-		}
-	}
-
-	plan := &app.Plan{
-		Provider:              "node",
-		Language:              language,
-		LanguageVersion:       languageVersion,
-		PackageManager:        string(pmInfo.Name),
-		PackageManagerVersion: pmInfo.Version,
-		DetectedFiles:         []string{"package.json"},
-		Metadata:              make(map[string]interface{}),
-	}
-
-	// Add runtime info for bun
-	if pmInfo.Name == PackageManagerBun {
-		plan.Metadata["runtime"] = "bun"
-		plan.Metadata["runtime_note"] = "Using Bun runtime (oven/bun image)"
-	}
+	plan := newPlan(pmInfo, nodeVersion)
 
 	// Add framework info
-	if fwInfo.Name != FrameworkNone {
-		plan.Framework = string(fwInfo.Name)
-		plan.FrameworkVersion = fwInfo.Version
-		if fwInfo.OutputType != OutputTypeNone {
-			plan.Metadata["output_type"] = string(fwInfo.OutputType)
-		}
-	}
+	setFramework(plan, fwInfo)
 
 	// Determine install command
 	plan.InstallCommand = pmInfo.GetInstallCommand()
+	plan.SetSource("install_command", fmt.Sprintf("%s default", pmInfo.Name))
 
 	// Determine build command
-	plan.BuildCommand = determineBuildCommand(pkg, pmInfo, fwInfo)
+	buildCommand, buildSource := determineBuildCommand(pkg, "package.json", pmInfo, fwInfo)
+	plan.BuildCommand = buildCommand
+	plan.SetSource("build_command", buildSource)
 
 	// Determine start command
-	plan.StartCommand = determineStartCommand(pkg, pmInfo, fwInfo)
+	startCommand, startSource := determineStartCommand(pkg, "package.json", pmInfo, fwInfo)
+	plan.StartCommand = startCommand
+	plan.SetSource("start_command", startSource)
 
 	// Add detected files to the list
 	plan.DetectedFiles = append(plan.DetectedFiles, detectRelevantFiles(ctx, pmInfo)...)
 
-	// Add additional metadata
+	// Add project info
 	if pkg.Name != "" {
-		plan.Metadata["name"] = pkg.Name
+		plan.Project.Name = pkg.Name
 	}
 	if pkg.Version != "" {
-		plan.Metadata["version"] = pkg.Version
+		plan.Project.Version = pkg.Version
 	}
-	if pkg.IsMonorepo() {
-		plan.Metadata["is_monorepo"] = true
-		plan.Metadata["workspaces"] = pkg.Workspaces.Packages
+	workspaces := append(append([]string{}, pkg.Workspaces.Packages...), readPnpmWorkspaceGlobs(ctx)...)
+	if len(workspaces) > 0 {
+		plan.Workspace.IsMonorepo = true
+		plan.Workspace.Globs = workspaces
 	}
 	if pkg.Type != "" {
-		plan.Metadata["module_type"] = pkg.Type
+		plan.Project.ModuleType = pkg.Type
 	}
 
-	// Detect native dependencies
-	nativeDeps := DetectNativeDependencies(pkg)
-	if len(nativeDeps) > 0 {
-		aptPackages := GetRequiredAptPackages(nativeDeps)
-		plan.Metadata["apt_packages"] = aptPackages
+	// Detect native dependencies, transitive ones through the lock file
+	registry, err := LoadNativeRegistry(ctx.Path)
+	if err != nil {
+		return nil, err
+	}
+	lock := LoadLockfile(ctx, pmInfo)
+	setNativeDependencies(plan, DetectNativeDependencies(registry, pkg, lock), lock)
 
-		// Track which native packages were detected
-		var detected []string
-		for _, dep := range nativeDeps {
-			detected = append(detected, dep.Package)
-		}
-		plan.Metadata["native_packages"] = detected
+	// Runner image of server apps (COOLPACK_NODE_RUNNER)
+	if err := setRunner(ctx, plan); err != nil {
+		return nil, err
 	}
 
 	// Check for base image override
 	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
-		plan.Metadata["base_image"] = baseImage
+		plan.Runtime.BaseImage = baseImage
+		plan.SetSource("runtime.base_image", "COOLPACK_BASE_IMAGE")
 	}
 
 	// Check for output directory override
 	if outputDir := ctx.Env["COOLPACK_SPA_OUTPUT_DIR"]; outputDir != "" {
-		plan.Metadata["output_dir_override"] = outputDir
+		plan.Static.OutputDir = outputDir
+		plan.SetSource("static.output_dir", "COOLPACK_SPA_OUTPUT_DIR")
 	}
 
 	// Detect Cypress for cache
 	if pkg.HasDependency("cypress") {
-		plan.Metadata["has_cypress"] = true
+		plan.Cache.Cypress = true
 	}
 
 	// Detect moon repo
 	if ctx.HasFile(".moon/workspace.yml") {
-		plan.Metadata["has_moon"] = true
+		plan.Cache.Moon = true
 	}
 
 	// Custom cache directories from package.json
 	if len(pkg.CacheDirectories) > 0 {
-		plan.Metadata["cache_directories"] = pkg.CacheDirectories
+		plan.Cache.Directories = pkg.CacheDirectories
 	}
 
 	// Detect SPA (only for static output)
-	if outputType := plan.Metadata["output_type"]; outputType == "static" {
+	if plan.Runtime.OutputType == "static" {
 		if isSPA := detectSPA(pkg, fwInfo); isSPA {
-			plan.Metadata["is_spa"] = true
+			plan.Static.SPA = true
+			plan.SetSource("static.spa", "client-side router dependency in package.json")
 		}
 	}
 
 	return plan, nil
 }
 
+// newPlan creates the plan shared by single apps and workspace apps
+func newPlan(pmInfo PackageManagerInfo, nodeVersion NodeVersionInfo) *app.Plan {
+	language, languageSource := "nodejs", "package.json"
+	languageVersion := nodeVersion.Version
+	versionSource := nodeVersion.Source
+	if pmInfo.Name == PackageManagerBun {
+		language, languageSource = "bun", pmInfo.Source
+		// For bun, use bun version as language version
+		if pmInfo.Version != "" {
+			languageVersion = pmInfo.Version
+			versionSource = pmInfo.Source
+		} else {
+			languageVersion = "latest"
+			versionSource = "default"
+		}
+	}
+
+	plan := &app.Plan{
+		Provider:              "node",
+		Language:              language,
+		LanguageVersion:       languageVersion,
+		PackageManager:        string(pmInfo.Name),
+		PackageManagerVersion: pmInfo.Version,
+		DetectedFiles:         []string{"package.json"},
+	}
+
+	plan.SetSource("language", languageSource)
+	plan.SetSource("language_version", versionSource)
+	plan.SetSource("package_manager", pmInfo.Source)
+
+	// Add runtime info for bun
+	if pmInfo.Name == PackageManagerBun {
+		plan.Runtime.Name = "bun"
+		plan.Runtime.Note = "Using Bun runtime (oven/bun image)"
+	}
+
+	return plan
+}
+
+// setFramework adds the detected framework, its output type and static output directory to the plan
+func setFramework(plan *app.Plan, fw FrameworkInfo) {
+	if fw.Name == FrameworkNone {
+		return
+	}
+	plan.Framework = string(fw.Name)
+	plan.FrameworkVersion = fw.Version
+	plan.SetSource("framework", fw.Source)
+	if fw.OutputType != OutputTypeNone {
+		plan.Runtime.OutputType = string(fw.OutputType)
+		if fw.OutputSource != "" {
+			plan.SetSource("runtime.output_type", fw.OutputSource)
+		} else {
+			plan.SetSource("runtime.output_type", fmt.Sprintf("%s default", fw.Name))
+		}
+	}
+	if fw.OutputType == OutputTypeStatic {
+		plan.Static.OutputDir = fw.GetDefaultOutputDir()
+		plan.SetSource("static.output_dir", fmt.Sprintf("%s default", fw.Name))
+	}
+}
+
+// setNativeDependencies adds the APT packages of the native dependencies to the plan
+// Transitive dependencies are listed with the top-level dependency that pulls them in
+func setNativeDependencies(plan *app.Plan, deps []NativeDependency, lock *Lockfile) {
+	if len(deps) == 0 {
+		return
+	}
+	buildPackages, runtimePackages := GetRequiredAptPackages(deps)
+	plan.Packages.Apt = buildPackages
+	if len(runtimePackages) > 0 {
+		plan.Packages.RuntimeApt = runtimePackages
+	}
+
+	source := "package.json"
+	var detected []string
+	for _, dep := range deps {
+		detected = append(detected, dep.String())
+		if dep.GlibcOnly {
+			plan.Packages.GlibcOnly = append(plan.Packages.GlibcOnly, dep.Package)
+		}
+		if dep.Via != "" {
+			source = "package.json, " + lock.File
+		}
+	}
+	plan.Packages.Native = detected
+	plan.SetSource("packages.native", source)
+}
+
 // detectSPA checks if the application is a Single Page Application
 // by looking for client-side router dependencies
 func detectSPA(pkg *PackageJSON, fw FrameworkInfo) bool {
@@ -207,56 +305,58 @@ func detectSPA(pkg *PackageJSON, fw FrameworkInfo) bool {
 	return false
 }
 
-// determineBuildCommand determines the build command to use
-func determineBuildCommand(pkg *PackageJSON, pm PackageManagerInfo, fw FrameworkInfo) string {
+// determineBuildCommand determines the build command to use and where it came from
+// manifest is the path of the package.json, for the source
+func determineBuildCommand(pkg *PackageJSON, manifest string, pm PackageManagerInfo, fw FrameworkInfo) (string, string) {
 	run := pm.GetRunCommand()
 
 	// Check for explicit build script
 	if pkg.HasScript("build") {
-		return run + " build"
+		return run + " build", manifest + " scripts.build"
 	}
 
 	// Use framework-specific defaults
 	if cmd := fw.GetDefaultBuildCommand(pm); cmd != "" {
-		return cmd
+		return cmd, fmt.Sprintf("%s default", fw.Name)
 	}
 
-	return ""
+	return "", ""
 }
 
-// determineStartCommand determines the start command to use
-func determineStartCommand(pkg *PackageJSON, pm PackageManagerInfo, fw FrameworkInfo) string {
+// determineStartCommand determines the start command to use and where it came from
+// manifest is the path of the package.json, for the source
+func determineStartCommand(pkg *PackageJSON, manifest string, pm PackageManagerInfo, fw FrameworkInfo) (string, string) {
 	run := pm.GetRunCommand()
 
 	// Check for explicit start script
 	if pkg.HasScript("start") {
-		return run + " start"
+		return run + " start", manifest + " scripts.start"
 	}
 
 	// Check for explicit serve script (common for SPAs)
 	if pkg.HasScript("serve") {
-		return run + " serve"
+		return run + " serve", manifest + " scripts.serve"
 	}
 
 	// Use framework-specific defaults
 	if cmd := fw.GetDefaultStartCommand(pm); cmd != "" {
-		return cmd
+		return cmd, fmt.Sprintf("%s default", fw.Name)
 	}
 
 	// Fallback: check main entry point
 	if pkg.Main != "" {
-		return fmt.Sprintf("node %s", pkg.Main)
+		return fmt.Sprintf("node %s", pkg.Main), manifest + " main"
 	}
 
 	// Check for common entry points
 	entryPoints := []string{"dist/index.js", "build/index.js", "index.js", "server.js", "app.js"}
 	for _, ep := range entryPoints {
 		if hasEntryPoint(pkg, ep) {
-			return fmt.Sprintf("node %s", ep)
+			return fmt.Sprintf("node %s", ep), "entry point " + ep
 		}
 	}
 
-	return ""
+	return "", ""
 }
 
 // hasEntryPoint checks if the entry point might exist (based on package.json hints)
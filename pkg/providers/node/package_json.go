@@ -17,6 +17,9 @@ type PackageJSON struct {
 	Engines          Engines           `json:"engines"`
 	PackageManager   string            `json:"packageManager"`
 	Workspaces       Workspaces        `json:"workspaces"`
+	Types            string            `json:"types"`
+	Module           string            `json:"module"`
+	Exports          json.RawMessage   `json:"exports"`
 	CacheDirectories []string          `json:"cacheDirectories"`
 }
 
@@ -129,6 +132,11 @@ func (p *PackageJSON) GetPackageManagerInfo() (name, version string) {
 	return name, version
 }
 
+// IsLibrary checks if the package is published for other packages to import
+func (p *PackageJSON) IsLibrary() bool {
+	return p.Types != "" || p.Module != "" || len(p.Exports) > 0
+}
+
 // IsMonorepo checks if this is a monorepo setup
 func (p *PackageJSON) IsMonorepo() bool {
 	return len(p.Workspaces.Packages) > 0
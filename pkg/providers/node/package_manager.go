@@ -1,6 +1,8 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/coollabsio/coolpack/pkg/app"
 )
 
@@ -19,6 +21,8 @@ const (
 type PackageManagerInfo struct {
 	Name    PackageManager
 	Version string
+	// Source is the file or field the package manager was detected from
+	Source string
 }
 
 // DetectPackageManager detects the package manager used by the project
@@ -31,14 +35,17 @@ func DetectPackageManager(ctx *app.Context, pkg *PackageJSON) PackageManagerInfo
 	info := PackageManagerInfo{
 		Name:    PackageManagerNPM,
 		Version: "",
+		Source:  "default",
 	}
 
 	// 1. Check packageManager field in package.json
 	if pmName, pmVersion := pkg.GetPackageManagerInfo(); pmName != "" {
+		source := fmt.Sprintf("package.json packageManager (%s)", pkg.PackageManager)
 		switch pmName {
 		case "pnpm":
 			info.Name = PackageManagerPNPM
 			info.Version = pmVersion
+			info.Source = source
 			return info
 		case "yarn":
 			// Check if it's Yarn Berry (2+)
@@ -48,14 +55,17 @@ func DetectPackageManager(ctx *app.Context, pkg *PackageJSON) PackageManagerInfo
 				info.Name = PackageManagerYarn1
 			}
 			info.Version = pmVersion
+			info.Source = source
 			return info
 		case "bun":
 			info.Name = PackageManagerBun
 			info.Version = pmVersion
+			info.Source = source
 			return info
 		case "npm":
 			info.Name = PackageManagerNPM
 			info.Version = pmVersion
+			info.Source = source
 			return info
 		}
 	}
@@ -63,41 +73,53 @@ func DetectPackageManager(ctx *app.Context, pkg *PackageJSON) PackageManagerInfo
 	// 2. Check lock files
 	if ctx.HasFile("pnpm-lock.yaml") {
 		info.Name = PackageManagerPNPM
+		info.Source = "pnpm-lock.yaml"
 		return info
 	}
 
-	if ctx.HasFile("bun.lockb") || ctx.HasFile("bun.lock") {
-		info.Name = PackageManagerBun
-		return info
+	for _, lockFile := range []string{"bun.lockb", "bun.lock"} {
+		if ctx.HasFile(lockFile) {
+			info.Name = PackageManagerBun
+			info.Source = lockFile
+			return info
+		}
 	}
 
 	// Check for Yarn Berry (.yarnrc.yml indicates Yarn 2+)
-	if ctx.HasFile(".yarnrc.yml") || ctx.HasFile(".yarnrc.yaml") {
-		info.Name = PackageManagerYarnBerry
-		return info
+	for _, rcFile := range []string{".yarnrc.yml", ".yarnrc.yaml"} {
+		if ctx.HasFile(rcFile) {
+			info.Name = PackageManagerYarnBerry
+			info.Source = rcFile
+			return info
+		}
 	}
 
 	if ctx.HasFile("yarn.lock") {
 		info.Name = PackageManagerYarn1
+		info.Source = "yarn.lock"
 		return info
 	}
 
 	if ctx.HasFile("package-lock.json") {
 		info.Name = PackageManagerNPM
+		info.Source = "package-lock.json"
 		return info
 	}
 
 	// 3. Check engines field
 	if pkg.Engines.PNPM != "" {
 		info.Name = PackageManagerPNPM
+		info.Source = "package.json engines.pnpm"
 		return info
 	}
 	if pkg.Engines.Bun != "" {
 		info.Name = PackageManagerBun
+		info.Source = "package.json engines.bun"
 		return info
 	}
 	if pkg.Engines.Yarn != "" {
 		info.Name = PackageManagerYarn1
+		info.Source = "package.json engines.yarn"
 		return info
 	}
 
@@ -147,6 +169,20 @@ func (pm PackageManagerInfo) GetRunCommand() string {
 	}
 }
 
+// GetExecCommand returns the command that runs a locally installed binary (e.g., turbo, nx)
+func (pm PackageManagerInfo) GetExecCommand() string {
+	switch pm.Name {
+	case PackageManagerPNPM:
+		return "pnpm exec"
+	case PackageManagerYarnBerry, PackageManagerYarn1:
+		return "yarn"
+	case PackageManagerBun:
+		return "bunx"
+	default:
+		return "npx"
+	}
+}
+
 // GetLockFile returns the lock file name for the package manager
 func (pm PackageManagerInfo) GetLockFile() string {
 	switch pm.Name {
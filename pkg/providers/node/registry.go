@@ -0,0 +1,87 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RegistryFileName is the native dependency registry read from the application root
+// and from the user's config directory (~/.config/coolpack)
+const RegistryFileName = "coolpack.native.json"
+
+// UserRegistryFileName is the registry file in the user's config directory
+const UserRegistryFileName = "native.json"
+
+// registryFile is the format of a native dependency registry file
+type registryFile struct {
+	Schema       string             `json:"$schema"`
+	Dependencies []NativeDependency `json:"dependencies"`
+}
+
+// UserRegistryPath returns the path of the user's registry file
+// ($XDG_CONFIG_HOME/coolpack/native.json or ~/.config/coolpack/native.json)
+func UserRegistryPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "coolpack", UserRegistryFileName)
+}
+
+// LoadNativeRegistry returns the native dependency registry of an application:
+// the entries of its coolpack.native.json, then of the user's registry file, then the
+// built-ins. The first entry of a package whose version range matches is used, so
+// files extend and override the built-ins
+func LoadNativeRegistry(dir string) ([]NativeDependency, error) {
+	var registry []NativeDependency
+	for _, file := range []string{filepath.Join(dir, RegistryFileName), UserRegistryPath()} {
+		if file == "" {
+			continue
+		}
+		entries, err := readRegistryFile(file)
+		if err != nil {
+			return nil, err
+		}
+		registry = append(registry, entries...)
+	}
+	return append(registry, NativeDependencies...), nil
+}
+
+// readRegistryFile reads the entries of a registry file, none if it doesn't exist
+func readRegistryFile(file string) ([]NativeDependency, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var registry registryFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&registry); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	for i := range registry.Dependencies {
+		dep := &registry.Dependencies[i]
+		if dep.Package == "" {
+			return nil, fmt.Errorf("%s: dependencies[%d] has no package", file, i)
+		}
+		if dep.Versions != "" {
+			if _, err := parseRange(dep.Versions); err != nil {
+				return nil, fmt.Errorf("%s: %s has an invalid version range: %w", file, dep.Package, err)
+			}
+		}
+		dep.Source = file
+	}
+	return registry.Dependencies, nil
+}
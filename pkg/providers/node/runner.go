@@ -0,0 +1,145 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// RunnerDistroless runs server apps on gcr.io/distroless/nodejs, which has no shell
+// or package manager and runs as nonroot
+const RunnerDistroless = "distroless"
+
+// nodeBins maps the CLIs of start scripts to the JavaScript file they run, so they can
+// be started with node instead of through the node_modules/.bin shims
+var nodeBins = map[string]string{
+	"next":               "node_modules/next/dist/bin/next",
+	"remix-serve":        "node_modules/@remix-run/serve/dist/cli.js",
+	"react-router-serve": "node_modules/@react-router/serve/bin.js",
+}
+
+// nodeStartCommands maps start commands that spawn the server through a shell to the
+// command they run
+var nodeStartCommands = map[string]string{
+	"nest start":  "node dist/main",
+	"vinxi start": "node .output/server/index.mjs",
+}
+
+// setRunner sets the runner of a server app (COOLPACK_NODE_RUNNER)
+// The default runner is the builder's image
+func setRunner(ctx *app.Context, plan *app.Plan) error {
+	runner := ctx.Env["COOLPACK_NODE_RUNNER"]
+	if runner == "" || plan.Runtime.OutputType == string(OutputTypeStatic) {
+		return nil
+	}
+	if runner != "node" && runner != RunnerDistroless {
+		return fmt.Errorf("unsupported Node.js runner %q (expected node or %s)", runner, RunnerDistroless)
+	}
+	if runner == RunnerDistroless && plan.Runtime.Name == "bun" {
+		return fmt.Errorf("the %s runner needs the Node.js runtime, Bun apps use the oven/bun image", RunnerDistroless)
+	}
+	plan.Runtime.Runner = runner
+	plan.SetSource("runtime.runner", "COOLPACK_NODE_RUNNER")
+	return nil
+}
+
+// ResolveStartCommand resolves the start command of a plan on the distroless runner to a
+// direct node invocation, following package manager runs into the package.json scripts
+// of the application at dir
+// It runs once every layer (Procfile, coolpack.toml, env vars, flags) has set the command
+func ResolveStartCommand(plan *app.Plan, dir string) error {
+	if plan.Provider != "node" || plan.Runtime.Runner != RunnerDistroless || plan.StartCommand == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	pkg, err := ParsePackageJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	if command := resolveNodeCommand(pkg, plan.StartCommand); command != plan.StartCommand {
+		plan.StartCommand = command
+		plan.SetSource("start_command", strings.TrimPrefix(plan.Source("start_command")+", resolved to node", ", "))
+	}
+	return nil
+}
+
+// resolveNodeCommand resolves a start command to a direct node invocation, following
+// package manager runs into the package.json scripts they run
+// Commands that can't be resolved (shell syntax, unknown CLIs) are returned as they are
+func resolveNodeCommand(pkg *PackageJSON, command string) string {
+	resolved := command
+	// Scripts may run other scripts
+	for range 5 {
+		if strings.ContainsAny(resolved, "$&|;<>'\"`") {
+			return command
+		}
+		fields := strings.Fields(resolved)
+		// The runner sets NODE_ENV=production
+		for len(fields) > 0 && (fields[0] == "cross-env" || fields[0] == "NODE_ENV=production") {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			return command
+		}
+
+		if fields[0] == "node" {
+			return strings.Join(fields, " ")
+		}
+		if name, args, ok := scriptRun(fields); ok {
+			if !pkg.HasScript(name) {
+				return command
+			}
+			resolved = strings.Join(append([]string{pkg.GetScript(name)}, args...), " ")
+			continue
+		}
+		if len(fields) > 1 {
+			if start, ok := nodeStartCommands[fields[0]+" "+fields[1]]; ok {
+				return strings.Join(append([]string{start}, fields[2:]...), " ")
+			}
+		}
+		if file, ok := nodeBins[fields[0]]; ok {
+			return strings.Join(append([]string{"node", file}, fields[1:]...), " ")
+		}
+		return command
+	}
+	return command
+}
+
+// scriptRun returns the package.json script a package manager command runs
+// (npm start, npm run serve, pnpm start) and the arguments passed to it
+func scriptRun(fields []string) (string, []string, bool) {
+	if len(fields) < 2 {
+		return "", nil, false
+	}
+	switch fields[0] {
+	case "npm", "yarn", "pnpm", "bun":
+	default:
+		return "", nil, false
+	}
+
+	args := fields[1:]
+	switch {
+	case args[0] == "run" || args[0] == "run-script":
+		args = args[1:]
+	case fields[0] == "npm" && args[0] != "start":
+		// npm only runs start without run
+		return "", nil, false
+	}
+	if len(args) == 0 {
+		return "", nil, false
+	}
+
+	rest := args[1:]
+	if len(rest) > 0 && rest[0] == "--" {
+		rest = rest[1:]
+	}
+	return args[0], rest, true
+}
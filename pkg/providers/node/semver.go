@@ -0,0 +1,185 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semver is a major.minor.patch version, pre-release and build suffixes are ignored
+type semver [3]int
+
+// versionRange is an npm semver range: alternatives (||) of comparators that all must match
+type versionRange [][]comparator
+
+// comparator is one condition of a range (>=1.2.0, ^1.2, 1.x, ...)
+type comparator struct {
+	op string
+	// lower is the version as written, missing parts are 0
+	lower semver
+	// upper is the first version past a partial version (1.2 -> 1.3.0)
+	upper semver
+	// parts is the number of version parts given, 0 for * or x
+	parts int
+}
+
+// versionNumberRegex matches the first version number of a dependency spec
+var versionNumberRegex = regexp.MustCompile(`\d+(?:\.\d+){0,2}`)
+
+// parseSemver parses a version, partial versions (1, 1.2, 1.x) report how many parts were given
+func parseSemver(s string) (semver, int, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, _, _ = strings.Cut(s, "-")
+
+	var v semver
+	if s == "" || s == "*" || s == "x" || s == "X" {
+		return v, 0, nil
+	}
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return v, 0, fmt.Errorf("invalid version %q", s)
+	}
+	parts := 0
+	for i, field := range fields {
+		if field == "*" || field == "x" || field == "X" {
+			break
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return v, 0, fmt.Errorf("invalid version %q", s)
+		}
+		v[i] = n
+		parts++
+	}
+	return v, parts, nil
+}
+
+// compareSemver returns -1, 0 or 1 as a is lower, equal or higher than b
+func compareSemver(a, b semver) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// bump returns the version with part i incremented and the parts after it zeroed
+func bump(v semver, i int) semver {
+	v[i]++
+	for j := i + 1; j < len(v); j++ {
+		v[j] = 0
+	}
+	return v
+}
+
+// parseRange parses an npm semver range (>=1.2 <2, ^1.2.3, ~1.2, 1.x, 1.2.3 - 2, a || b)
+func parseRange(s string) (versionRange, error) {
+	var r versionRange
+	for _, alternative := range strings.Split(s, "||") {
+		var comparators []comparator
+
+		// Hyphen ranges include both ends
+		if from, to, ok := strings.Cut(alternative, " - "); ok {
+			lower, err := newComparator(">=", from)
+			if err != nil {
+				return nil, err
+			}
+			upper, err := newComparator("<=", to)
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, []comparator{lower, upper})
+			continue
+		}
+
+		fields := strings.Fields(alternative)
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			op := field[:len(field)-len(strings.TrimLeft(field, "<>=^~"))]
+			version := field[len(op):]
+			// An operator may be separated from its version (>= 1.2)
+			if version == "" && op != "" && i+1 < len(fields) {
+				i++
+				version = fields[i]
+			}
+			c, err := newComparator(op, version)
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, c)
+		}
+		r = append(r, comparators)
+	}
+	return r, nil
+}
+
+// newComparator parses the version of a comparator
+func newComparator(op, version string) (comparator, error) {
+	switch op {
+	case "", "=", ">", ">=", "<", "<=", "^", "~":
+	default:
+		return comparator{}, fmt.Errorf("invalid operator %q", op)
+	}
+	lower, parts, err := parseSemver(version)
+	if err != nil {
+		return comparator{}, err
+	}
+	c := comparator{op: op, lower: lower, upper: lower, parts: parts}
+	if parts > 0 {
+		c.upper = bump(lower, parts-1)
+	}
+	return c, nil
+}
+
+// matches reports whether a version satisfies the comparator
+func (c comparator) matches(v semver) bool {
+	if c.parts == 0 {
+		// * matches everything, and nothing is above or below it
+		return c.op != ">" && c.op != "<"
+	}
+	switch c.op {
+	case ">=":
+		return compareSemver(v, c.lower) >= 0
+	case ">":
+		return compareSemver(v, c.upper) >= 0
+	case "<":
+		return compareSemver(v, c.lower) < 0
+	case "<=":
+		return compareSemver(v, c.upper) < 0
+	case "^":
+		// Changes that don't modify the left-most non-zero part
+		i := 0
+		for i < c.parts-1 && c.lower[i] == 0 {
+			i++
+		}
+		return compareSemver(v, c.lower) >= 0 && compareSemver(v, bump(c.lower, i)) < 0
+	case "~":
+		// Patch changes when a minor version is given, minor changes otherwise
+		i := min(1, c.parts-1)
+		return compareSemver(v, c.lower) >= 0 && compareSemver(v, bump(c.lower, i)) < 0
+	}
+	return compareSemver(v, c.lower) >= 0 && compareSemver(v, c.upper) < 0
+}
+
+// matches reports whether a version satisfies the range
+func (r versionRange) matches(v semver) bool {
+	for _, comparators := range r {
+		ok := true
+		for _, c := range comparators {
+			if !c.matches(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -9,6 +10,13 @@ import (
 
 const DefaultNodeVersion = "24"
 
+// NodeVersionInfo contains the detected Node.js version and where it came from
+type NodeVersionInfo struct {
+	Version string
+	// Source is the file, environment variable or rule the version came from
+	Source string
+}
+
 // DetectNodeVersion detects the Node.js version to use
 // Priority:
 // 1. COOLPACK_NODE_VERSION environment variable
@@ -19,21 +27,21 @@ const DefaultNodeVersion = "24"
 // 6. .tool-versions file (asdf)
 // 7. mise.toml file
 // 8. Default to 22
-func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) string {
+func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) NodeVersionInfo {
 	// 1. Check COOLPACK_NODE_VERSION env var
 	if v := ctx.Env["COOLPACK_NODE_VERSION"]; v != "" {
-		return normalizeVersion(v)
+		return NodeVersionInfo{Version: normalizeVersion(v), Source: "COOLPACK_NODE_VERSION"}
 	}
 
 	// 2. Check NODE_VERSION env var
 	if v := ctx.Env["NODE_VERSION"]; v != "" {
-		return normalizeVersion(v)
+		return NodeVersionInfo{Version: normalizeVersion(v), Source: "NODE_VERSION"}
 	}
 
 	// 3. Check engines.node in package.json
 	if pkg != nil && pkg.Engines.Node != "" {
 		if v := parseEngineVersion(pkg.Engines.Node); v != "" {
-			return v
+			return NodeVersionInfo{Version: v, Source: fmt.Sprintf("package.json engines.node (%s)", pkg.Engines.Node)}
 		}
 	}
 
@@ -41,7 +49,7 @@ func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) string {
 	if ctx.HasFile(".nvmrc") {
 		if data, err := ctx.ReadFile(".nvmrc"); err == nil {
 			if v := parseVersionFile(string(data)); v != "" {
-				return v
+				return NodeVersionInfo{Version: v, Source: ".nvmrc"}
 			}
 		}
 	}
@@ -50,7 +58,7 @@ func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) string {
 	if ctx.HasFile(".node-version") {
 		if data, err := ctx.ReadFile(".node-version"); err == nil {
 			if v := parseVersionFile(string(data)); v != "" {
-				return v
+				return NodeVersionInfo{Version: v, Source: ".node-version"}
 			}
 		}
 	}
@@ -58,8 +66,8 @@ func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) string {
 	// 6. Check .tool-versions file (asdf format)
 	if ctx.HasFile(".tool-versions") {
 		if data, err := ctx.ReadFile(".tool-versions"); err == nil {
-			if v := parseToolVersions(string(data), "nodejs"); v != "" {
-				return v
+			if v := app.ParseToolVersions(string(data), "nodejs"); v != "" {
+				return NodeVersionInfo{Version: v, Source: ".tool-versions"}
 			}
 		}
 	}
@@ -68,13 +76,13 @@ func DetectNodeVersion(ctx *app.Context, pkg *PackageJSON) string {
 	if ctx.HasFile("mise.toml") {
 		if data, err := ctx.ReadFile("mise.toml"); err == nil {
 			if v := parseMiseToml(string(data)); v != "" {
-				return v
+				return NodeVersionInfo{Version: v, Source: "mise.toml"}
 			}
 		}
 	}
 
 	// 8. Default
-	return DefaultNodeVersion
+	return NodeVersionInfo{Version: DefaultNodeVersion, Source: "default"}
 }
 
 // normalizeVersion cleans up version strings
@@ -117,20 +125,6 @@ func parseEngineVersion(constraint string) string {
 	return ""
 }
 
-// parseToolVersions parses .tool-versions file (asdf format)
-// Format: tool-name version
-func parseToolVersions(content string, tool string) string {
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		parts := strings.Fields(line)
-		if len(parts) >= 2 && parts[0] == tool {
-			return normalizeVersion(parts[1])
-		}
-	}
-	return ""
-}
-
 // parseMiseToml extracts Node version from mise.toml
 // Simple parser - just looks for node = "version"
 func parseMiseToml(content string) string {
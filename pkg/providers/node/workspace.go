@@ -0,0 +1,585 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// WorkspaceTool is the tool that orchestrates a monorepo
+type WorkspaceTool string
+
+const (
+	// WorkspaceToolTurbo prunes the workspace with turbo prune --docker
+	WorkspaceToolTurbo WorkspaceTool = "turbo"
+	// WorkspaceToolMoon prunes the workspace with moon docker scaffold
+	WorkspaceToolMoon WorkspaceTool = "moon"
+	// WorkspaceToolNx builds through nx, installing with the package manager
+	WorkspaceToolNx WorkspaceTool = "nx"
+	// WorkspaceToolPackageManager uses the package manager's own workspace filters
+	WorkspaceToolPackageManager WorkspaceTool = "workspaces"
+)
+
+// WorkspaceApp is a deployable application inside a monorepo
+type WorkspaceApp struct {
+	// Name is the package (or Nx/Moon project) name used to filter workspace commands
+	Name string `json:"name"`
+
+	// Path is the app directory relative to the repository root (e.g., "apps/web")
+	Path string `json:"path"`
+
+	Framework  string `json:"framework,omitempty"`
+	OutputType string `json:"output_type,omitempty"`
+
+	// Project is the Nx or Moon project id, when it differs from the package name
+	Project string `json:"project,omitempty"`
+
+	// pkg is the app's package.json (nil for Nx projects without one)
+	pkg *PackageJSON
+
+	// buildTarget is true if the Nx or Moon project declares a build target
+	buildTarget bool
+
+	// outputPath is the Nx build output relative to the repository root
+	outputPath string
+
+	// executor is the Nx executor of the build target (e.g., "@nx/vite:build")
+	executor string
+}
+
+// Workspace is a monorepo and the packages it contains
+type Workspace struct {
+	Tool     WorkspaceTool
+	Packages []*workspacePackage
+}
+
+// workspacePackage is a package or project found in the workspace
+type workspacePackage struct {
+	Path string
+	Name string
+	Pkg  *PackageJSON
+
+	// projectType is the Nx projectType or Moon type ("application", "library", ...)
+	projectType string
+	// project is the Nx or Moon project id
+	project     string
+	buildTarget bool
+	outputPath  string
+	executor    string
+}
+
+var (
+	// pnpmPackagesKeyRegex matches the packages key of pnpm-workspace.yaml
+	pnpmPackagesKeyRegex = regexp.MustCompile(`^packages:\s*$`)
+
+	// yamlListItemRegex matches a YAML list item, with optional quotes
+	yamlListItemRegex = regexp.MustCompile(`^\s+-\s*["']?([^"'#]+?)["']?\s*(?:#.*)?$`)
+
+	// yamlKeyValueRegex matches an indented "key: value" pair, with optional quotes
+	yamlKeyValueRegex = regexp.MustCompile(`^\s+([\w@/.-]+):\s*["']?([^"'#]*?)["']?\s*(?:#.*)?$`)
+
+	// moonTypeRegex matches the project type in moon.yml (type in moon v1, layer in v2)
+	moonTypeRegex = regexp.MustCompile(`(?m)^(?:type|layer):\s*["']?(\w+)`)
+)
+
+// nxProject is the part of an Nx project.json Coolpack reads
+type nxProject struct {
+	Name        string `json:"name"`
+	ProjectType string `json:"projectType"`
+	Targets     map[string]struct {
+		Executor string `json:"executor"`
+		Options  struct {
+			OutputPath string `json:"outputPath"`
+		} `json:"options"`
+	} `json:"targets"`
+}
+
+// nxLayout is the workspaceLayout of nx.json
+type nxLayout struct {
+	WorkspaceLayout struct {
+		AppsDir string `json:"appsDir"`
+		LibsDir string `json:"libsDir"`
+	} `json:"workspaceLayout"`
+}
+
+// DetectWorkspaceTool returns the tool orchestrating the monorepo
+// Priority: Turborepo > Moon > Nx > package manager workspaces
+func DetectWorkspaceTool(ctx *app.Context) WorkspaceTool {
+	switch {
+	case ctx.HasFile("turbo.json") || ctx.HasFile("turbo.jsonc"):
+		return WorkspaceToolTurbo
+	case ctx.HasFile(".moon/workspace.yml"):
+		return WorkspaceToolMoon
+	case ctx.HasFile("nx.json"):
+		return WorkspaceToolNx
+	default:
+		return WorkspaceToolPackageManager
+	}
+}
+
+// DiscoverWorkspace finds the packages of a monorepo
+// Returns nil if the project isn't a monorepo
+func DiscoverWorkspace(ctx *app.Context, root *PackageJSON) *Workspace {
+	ws := &Workspace{Tool: DetectWorkspaceTool(ctx)}
+	seen := make(map[string]*workspacePackage)
+
+	add := func(dir string) *workspacePackage {
+		dir = path.Clean(dir)
+		if dir == "." {
+			return nil
+		}
+		if wp, ok := seen[dir]; ok {
+			return wp
+		}
+		wp := &workspacePackage{Path: dir, Name: path.Base(dir)}
+		if data, err := ctx.ReadFile(path.Join(dir, "package.json")); err == nil {
+			if pkg, err := ParsePackageJSON(data); err == nil {
+				wp.Pkg = pkg
+				if pkg.Name != "" {
+					wp.Name = pkg.Name
+				}
+			}
+		}
+		seen[dir] = wp
+		ws.Packages = append(ws.Packages, wp)
+		return wp
+	}
+
+	// npm, yarn and bun workspaces, then pnpm-workspace.yaml
+	var globs []string
+	if root != nil {
+		globs = append(globs, root.Workspaces.Packages...)
+	}
+	globs = append(globs, readPnpmWorkspaceGlobs(ctx)...)
+	for _, dir := range expandWorkspaceGlobs(ctx, globs, "package.json") {
+		add(dir)
+	}
+
+	// Nx project.json files (integrated repos have no package.json per project)
+	if ctx.HasFile("nx.json") {
+		for _, dir := range discoverNxProjects(ctx) {
+			data, err := ctx.ReadFile(path.Join(dir, "project.json"))
+			if err != nil {
+				continue
+			}
+			var project nxProject
+			if err := json.Unmarshal(data, &project); err != nil {
+				continue
+			}
+			wp := add(dir)
+			if wp == nil {
+				continue
+			}
+			wp.projectType = project.ProjectType
+			wp.project = project.Name
+			if wp.project == "" {
+				wp.project = path.Base(dir)
+			}
+			if wp.Pkg == nil {
+				wp.Name = wp.project
+			}
+			if build, ok := project.Targets["build"]; ok {
+				wp.buildTarget = true
+				wp.outputPath = build.Options.OutputPath
+				wp.executor = build.Executor
+			}
+		}
+	}
+
+	// Moon projects
+	if ctx.HasFile(".moon/workspace.yml") {
+		for id, dir := range readMoonProjects(ctx) {
+			if !ctx.HasFile(path.Join(dir, "package.json")) {
+				continue
+			}
+			wp := add(dir)
+			if wp == nil {
+				continue
+			}
+			wp.project = id
+			if data, err := ctx.ReadFile(path.Join(dir, "moon.yml")); err == nil {
+				if matches := moonTypeRegex.FindSubmatch(data); len(matches) > 1 {
+					wp.projectType = string(matches[1])
+				}
+				wp.buildTarget = hasMoonBuildTask(string(data))
+			}
+		}
+	}
+
+	if len(ws.Packages) == 0 {
+		return nil
+	}
+
+	sort.Slice(ws.Packages, func(i, j int) bool {
+		return ws.Packages[i].Path < ws.Packages[j].Path
+	})
+	return ws
+}
+
+// Apps returns the deployable applications of the workspace
+func (ws *Workspace) Apps(ctx *app.Context, root *PackageJSON) []WorkspaceApp {
+	var apps []WorkspaceApp
+	for _, wp := range ws.Packages {
+		wsApp := WorkspaceApp{
+			Name:        wp.Name,
+			Path:        wp.Path,
+			pkg:         wp.Pkg,
+			buildTarget: wp.buildTarget,
+			outputPath:  wp.outputPath,
+			executor:    wp.executor,
+		}
+		if wp.project != "" && wp.project != wp.Name {
+			wsApp.Project = wp.project
+		}
+
+		fwInfo := wsApp.detectFramework(ctx, root)
+		if !wp.isApp(fwInfo) {
+			continue
+		}
+		wsApp.Framework = string(fwInfo.Name)
+		wsApp.OutputType = string(fwInfo.OutputType)
+		apps = append(apps, wsApp)
+	}
+	return apps
+}
+
+// detectFramework detects the framework of the app from its own directory
+// Nx integrated projects share the root dependencies, so their build executor and
+// config files decide instead
+func (a *WorkspaceApp) detectFramework(ctx *app.Context, root *PackageJSON) FrameworkInfo {
+	appCtx := app.NewContext(path.Join(ctx.Path, a.Path))
+	appCtx.Env = ctx.Env
+	if a.pkg != nil {
+		return DetectFramework(appCtx, a.pkg)
+	}
+
+	info := FrameworkInfo{Name: FrameworkNone, OutputType: OutputTypeNone}
+	switch {
+	case strings.Contains(a.executor, "/next:") || appCtx.HasMatch("next.config.*"):
+		info.Name, info.OutputType = FrameworkNextJS, OutputTypeServer
+		info.Version = cleanVersion(root.GetDependencyVersion("next"))
+	case strings.Contains(a.executor, "/remix:"):
+		info.Name, info.OutputType = FrameworkRemix, OutputTypeServer
+	case strings.Contains(a.executor, "angular"):
+		info.Name, info.OutputType = FrameworkAngular, OutputTypeStatic
+	case strings.Contains(a.executor, "/vite:") || appCtx.HasMatch("vite.config.*"):
+		info.Name, info.OutputType = FrameworkVite, OutputTypeStatic
+	case strings.Contains(a.executor, "/esbuild:") || strings.Contains(a.executor, "/node:") ||
+		strings.Contains(a.executor, "/webpack:") || strings.Contains(a.executor, "/js:"):
+		// Bundled Node.js server
+		info.OutputType = OutputTypeServer
+	}
+	return info
+}
+
+// isApp decides whether a workspace package is deployable
+// Nx and Moon project types win, then the apps/ convention, then a start script
+// or a framework in a package that isn't published as a library
+func (wp *workspacePackage) isApp(fwInfo FrameworkInfo) bool {
+	if wp.projectType != "" {
+		return wp.projectType == "application"
+	}
+	if strings.HasPrefix(wp.Path, "apps/") {
+		return true
+	}
+	if wp.Pkg == nil {
+		return false
+	}
+	if wp.Pkg.HasScript("start") || wp.Pkg.HasScript("serve") {
+		return true
+	}
+	return fwInfo.OutputType != OutputTypeNone && !wp.Pkg.IsLibrary()
+}
+
+// ProjectID returns the Nx or Moon project id of the app
+func (a *WorkspaceApp) ProjectID() string {
+	if a.Project != "" {
+		return a.Project
+	}
+	return a.Name
+}
+
+// dependencyOrder returns the workspace packages an app depends on, directly or through
+// other workspace packages, in build order (dependencies first)
+func (ws *Workspace) dependencyOrder(wsApp *WorkspaceApp) []*workspacePackage {
+	byName := make(map[string]*workspacePackage)
+	for _, p := range ws.Packages {
+		if p.Pkg != nil && p.Name != "" {
+			byName[p.Name] = p
+		}
+	}
+
+	visited := map[string]bool{wsApp.Name: true}
+	var order []*workspacePackage
+	var visit func(pkg *PackageJSON)
+	visit = func(pkg *PackageJSON) {
+		for _, name := range sortedKeys(pkg.Dependencies, pkg.DevDependencies) {
+			dep, ok := byName[name]
+			if !ok || visited[name] {
+				continue
+			}
+			visited[name] = true
+			visit(dep.Pkg)
+			order = append(order, dep)
+		}
+	}
+	if wsApp.pkg != nil {
+		visit(wsApp.pkg)
+	}
+	return order
+}
+
+// FindApp returns the app matching a path (apps/web) or package name (@acme/web)
+func (ws *Workspace) FindApp(ctx *app.Context, root *PackageJSON, selector string) (*WorkspaceApp, error) {
+	apps := ws.Apps(ctx, root)
+	cleaned := path.Clean(strings.TrimPrefix(selector, "./"))
+	for i := range apps {
+		if apps[i].Path == cleaned || apps[i].Name == selector || apps[i].Project == selector {
+			return &apps[i], nil
+		}
+	}
+
+	var available []string
+	for _, a := range apps {
+		available = append(available, a.Path)
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("app %s not found: no deployable apps in the workspace", selector)
+	}
+	return nil, fmt.Errorf("app %s not found (available: %s)", selector, strings.Join(available, ", "))
+}
+
+// DiscoverApps lists the deployable apps of the monorepo at the context path
+// Returns nil if the project isn't a monorepo
+func DiscoverApps(ctx *app.Context) ([]WorkspaceApp, error) {
+	data, err := ctx.ReadFile("package.json")
+	if err != nil {
+		return nil, nil
+	}
+	root, err := ParsePackageJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+	ws := DiscoverWorkspace(ctx, root)
+	if ws == nil {
+		return nil, nil
+	}
+	return ws.Apps(ctx, root), nil
+}
+
+// readPnpmWorkspaceGlobs reads the packages list of pnpm-workspace.yaml
+func readPnpmWorkspaceGlobs(ctx *app.Context) []string {
+	data, err := ctx.ReadFile("pnpm-workspace.yaml")
+	if err != nil {
+		return nil
+	}
+
+	var globs []string
+	inPackages := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if pnpmPackagesKeyRegex.MatchString(line) {
+			inPackages = true
+			continue
+		}
+		if !inPackages || strings.TrimSpace(line) == "" {
+			continue
+		}
+		matches := yamlListItemRegex.FindStringSubmatch(line)
+		if matches == nil {
+			// Any other key ends the list
+			if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				inPackages = false
+			}
+			continue
+		}
+		globs = append(globs, matches[1])
+	}
+	return globs
+}
+
+// readMoonProjects reads the projects of .moon/workspace.yml as id -> directory
+// Supports a list of globs, a map of sources, and the globs/sources object form
+func readMoonProjects(ctx *app.Context) map[string]string {
+	data, err := ctx.ReadFile(".moon/workspace.yml")
+	if err != nil {
+		return nil
+	}
+
+	projects := make(map[string]string)
+	var globs []string
+	inProjects := false
+	section := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inProjects = strings.HasPrefix(line, "projects:")
+			section = ""
+			// Inline list: projects: ['apps/*', 'packages/*']
+			if inProjects {
+				globs = append(globs, parseInlineList(strings.TrimPrefix(line, "projects:"))...)
+			}
+			continue
+		}
+		if !inProjects {
+			continue
+		}
+
+		if matches := yamlListItemRegex.FindStringSubmatch(line); matches != nil {
+			if section == "" || section == "globs" {
+				globs = append(globs, matches[1])
+			}
+			continue
+		}
+		matches := yamlKeyValueRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		key, value := matches[1], matches[2]
+		switch {
+		case key == "globs" || key == "sources":
+			section = key
+			if key == "globs" {
+				globs = append(globs, parseInlineList(value)...)
+			}
+		case value != "" && section != "globs":
+			projects[key] = path.Clean(value)
+		}
+	}
+
+	for _, dir := range expandWorkspaceGlobs(ctx, globs, "moon.yml", "package.json") {
+		id := path.Base(dir)
+		if _, ok := projects[id]; !ok {
+			projects[id] = dir
+		}
+	}
+	return projects
+}
+
+// hasMoonBuildTask checks if moon.yml declares a build task
+func hasMoonBuildTask(content string) bool {
+	inTasks := false
+	indent := ""
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inTasks = strings.HasPrefix(line, "tasks:")
+			indent = ""
+			continue
+		}
+		if !inTasks {
+			continue
+		}
+		// Task names are at the first indentation level of the tasks block
+		trimmed := strings.TrimLeft(line, " \t")
+		if indent == "" {
+			indent = line[:len(line)-len(trimmed)]
+		}
+		if line[:len(line)-len(trimmed)] == indent && strings.HasPrefix(trimmed, "build:") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseInlineList parses a YAML flow sequence (['a', "b"])
+func parseInlineList(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(strings.Trim(value, "[]"), ",") {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// discoverNxProjects finds directories with a project.json
+// Looks in the workspaceLayout directories of nx.json plus the usual apps/, libs/ and packages/
+func discoverNxProjects(ctx *app.Context) []string {
+	dirs := []string{"apps", "libs", "packages"}
+	if data, err := ctx.ReadFile("nx.json"); err == nil {
+		var layout nxLayout
+		if json.Unmarshal(data, &layout) == nil {
+			for _, dir := range []string{layout.WorkspaceLayout.AppsDir, layout.WorkspaceLayout.LibsDir} {
+				if dir != "" {
+					dirs = append(dirs, strings.Trim(dir, "/"))
+				}
+			}
+		}
+	}
+
+	var globs []string
+	for _, dir := range dirs {
+		globs = append(globs, dir+"/*", dir+"/*/*")
+	}
+	return expandWorkspaceGlobs(ctx, globs, "project.json")
+}
+
+// expandWorkspaceGlobs expands workspace globs into package directories containing one of
+// the marker files. Negated globs (!packages/internal) exclude directories, and ** matches
+// up to three levels, which covers nested layouts without walking node_modules
+func expandWorkspaceGlobs(ctx *app.Context, globs []string, markers ...string) []string {
+	var include, exclude []string
+	for _, glob := range globs {
+		glob = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(glob), "./"), "/")
+		if glob == "" {
+			continue
+		}
+		if strings.HasPrefix(glob, "!") {
+			exclude = append(exclude, strings.TrimPrefix(strings.TrimPrefix(glob, "!"), "./"))
+			continue
+		}
+		if strings.Contains(glob, "**") {
+			for _, depth := range []string{"*", "*/*", "*/*/*"} {
+				include = append(include, strings.Replace(glob, "**", depth, 1))
+			}
+			continue
+		}
+		include = append(include, glob)
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, glob := range include {
+		for _, marker := range markers {
+			matches, err := ctx.ListFiles(path.Join(glob, marker))
+			if err != nil {
+				continue
+			}
+			for _, match := range matches {
+				dir := path.Dir(strings.ReplaceAll(match, "\\", "/"))
+				if seen[dir] || strings.Contains(dir, "node_modules") || isExcluded(dir, exclude) {
+					continue
+				}
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// isExcluded checks a directory against negated workspace globs
+func isExcluded(dir string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(strings.ReplaceAll(pattern, "**", "*"), dir); ok || pattern == dir {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+package node
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// planWorkspaceApp generates a build plan for one app of a monorepo
+// The install only covers the app's dependency graph: turbo prune and moon docker
+// scaffold prune the workspace, the package managers install with workspace filters,
+// and pnpm deploys the app with its production dependencies
+func planWorkspaceApp(ctx *app.Context, root *PackageJSON, selector string) (*app.Plan, error) {
+	ws := DiscoverWorkspace(ctx, root)
+	if ws == nil {
+		return nil, fmt.Errorf("app %s selected, but package.json declares no workspaces", selector)
+	}
+	if ctx.Env["COOLPACK_NODE_RUNNER"] == RunnerDistroless {
+		return nil, fmt.Errorf("the %s runner isn't supported for workspace apps", RunnerDistroless)
+	}
+	wsApp, err := ws.FindApp(ctx, root, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nx integrated projects share the root package.json and build from the root
+	appPkg := wsApp.pkg
+	workdir := wsApp.Path
+	if appPkg == nil {
+		appPkg = root
+		workdir = ""
+	}
+
+	// Package manager and lock file live at the root
+	pmInfo := DetectPackageManager(ctx, root)
+
+	// Node.js version: root settings first, then the app's engines
+	versionPkg := root
+	if root.Engines.Node == "" && appPkg.Engines.Node != "" {
+		versionPkg = appPkg
+	}
+	nodeVersion := DetectNodeVersion(ctx, versionPkg)
+	if versionPkg != root && strings.HasPrefix(nodeVersion.Source, "package.json") {
+		nodeVersion.Source = path.Join(wsApp.Path, nodeVersion.Source)
+	}
+
+	// Framework config files live in the app directory
+	fwInfo := wsApp.detectFramework(ctx, root)
+
+	plan := newPlan(pmInfo, nodeVersion)
+	setFramework(plan, fwInfo)
+
+	plan.DetectedFiles = append(plan.DetectedFiles, detectRelevantFiles(ctx, pmInfo)...)
+	for _, f := range []string{"pnpm-workspace.yaml", "turbo.json", "nx.json", ".moon/workspace.yml"} {
+		if ctx.HasFile(f) {
+			plan.DetectedFiles = append(plan.DetectedFiles, f)
+		}
+	}
+	if wsApp.pkg != nil {
+		plan.DetectedFiles = append(plan.DetectedFiles, path.Join(wsApp.Path, "package.json"))
+	}
+
+	installCommand, installSource := workspaceInstallCommand(ws.Tool, pmInfo, wsApp)
+	plan.InstallCommand = installCommand
+	plan.SetSource("install_command", installSource)
+	buildCommand, buildSource := workspaceBuildCommand(ws.Tool, pmInfo, wsApp, appPkg, fwInfo, ws.dependencyOrder(wsApp))
+	plan.BuildCommand = buildCommand
+	plan.SetSource("build_command", buildSource)
+	startCommand, startSource := workspaceStartCommand(pmInfo, wsApp, appPkg, fwInfo)
+	plan.StartCommand = startCommand
+	plan.SetSource("start_command", startSource)
+
+	plan.Workspace.IsMonorepo = true
+	plan.Workspace.Tool = string(ws.Tool)
+	plan.Workspace.App = wsApp.Path
+	plan.SetSource("workspace.app", "--app or COOLPACK_APP")
+	plan.Workspace.AppName = wsApp.Name
+	if wsApp.Project != "" {
+		plan.Workspace.AppProject = wsApp.Project
+	}
+	plan.Workspace.Workdir = workdir
+
+	// Workspace manifests are copied before the install for better caching
+	manifests := []string{}
+	for _, wp := range ws.Packages {
+		if wp.Pkg != nil {
+			manifests = append(manifests, path.Join(wp.Path, "package.json"))
+		}
+	}
+	plan.Workspace.Manifests = manifests
+
+	if ws.Tool == WorkspaceToolTurbo {
+		plan.Workspace.TurboVersion = turboVersion(root)
+	}
+
+	if appPkg.Name != "" {
+		plan.Project.Name = appPkg.Name
+	}
+	if appPkg.Version != "" {
+		plan.Project.Version = appPkg.Version
+	}
+	if appPkg.Type != "" {
+		plan.Project.ModuleType = appPkg.Type
+	}
+
+	// Native dependencies of the app and the root (shared tooling)
+	registry, err := LoadNativeRegistry(ctx.Path)
+	if err != nil {
+		return nil, err
+	}
+	lock := LoadLockfile(ctx, pmInfo)
+	nativeDeps := DetectNativeDependencies(registry, appPkg, lock)
+	if appPkg != root {
+		seen := make(map[string]bool)
+		for _, dep := range nativeDeps {
+			seen[dep.Package] = true
+		}
+		for _, dep := range DetectNativeDependencies(registry, root, lock) {
+			if !seen[dep.Package] {
+				nativeDeps = append(nativeDeps, dep)
+			}
+		}
+	}
+	setNativeDependencies(plan, nativeDeps, lock)
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+		plan.SetSource("runtime.base_image", "COOLPACK_BASE_IMAGE")
+	}
+
+	// Output directory: override > Nx outputPath > framework default (relative to the workdir)
+	if outputDir := ctx.Env["COOLPACK_SPA_OUTPUT_DIR"]; outputDir != "" {
+		plan.Static.OutputDir = outputDir
+		plan.SetSource("static.output_dir", "COOLPACK_SPA_OUTPUT_DIR")
+	} else if outputPath := nxOutputPath(wsApp); outputPath != "" {
+		plan.Static.OutputDir = relativeTo(workdir, outputPath)
+		plan.SetSource("static.output_dir", "Nx build target outputPath")
+	}
+
+	if appPkg.HasDependency("cypress") || root.HasDependency("cypress") {
+		plan.Cache.Cypress = true
+	}
+	if ctx.HasFile(".moon/workspace.yml") {
+		plan.Cache.Moon = true
+	}
+	if len(appPkg.CacheDirectories) > 0 {
+		plan.Cache.Directories = appPkg.CacheDirectories
+	}
+
+	if plan.Runtime.OutputType == "static" {
+		if detectSPA(appPkg, fwInfo) {
+			plan.Static.SPA = true
+			plan.SetSource("static.spa", "client-side router dependency in package.json")
+		}
+	}
+
+	return plan, nil
+}
+
+// workspaceInstallCommand installs the dependencies of the app's workspace graph
+// Returns the command and the rule it came from
+func workspaceInstallCommand(tool WorkspaceTool, pm PackageManagerInfo, wsApp *WorkspaceApp) (string, string) {
+	switch tool {
+	case WorkspaceToolTurbo:
+		// turbo prune already reduced the workspace and lock file to the app's graph
+		return pm.GetInstallCommand(), "install of the workspace pruned by turbo prune"
+	case WorkspaceToolMoon:
+		return "moon docker setup", "moon docker workflow"
+	}
+
+	// Nx integrated projects have no package of their own to filter on
+	if wsApp.pkg == nil {
+		return pm.GetInstallCommand(), "root install for an Nx integrated project"
+	}
+
+	switch pm.Name {
+	case PackageManagerPNPM:
+		return fmt.Sprintf("pnpm install --frozen-lockfile --filter \"%s...\" --filter .", wsApp.Name), "pnpm workspace filter"
+	case PackageManagerYarnBerry:
+		return fmt.Sprintf("yarn workspaces focus %s", wsApp.Name), "yarn workspaces focus"
+	case PackageManagerNPM:
+		return fmt.Sprintf("npm ci --workspace %s --include-workspace-root", wsApp.Path), "npm workspace filter"
+	default:
+		// Yarn 1 and Bun have no install filter
+		return pm.GetInstallCommand(), fmt.Sprintf("full install (%s has no workspace filter)", pm.Name)
+	}
+}
+
+// workspaceBuildCommand builds the app after the workspace packages it depends on
+// deps are those packages in build order, for package managers that can't select them
+// Returns the command and the rule it came from
+func workspaceBuildCommand(tool WorkspaceTool, pm PackageManagerInfo, wsApp *WorkspaceApp, pkg *PackageJSON, fw FrameworkInfo, deps []*workspacePackage) (string, string) {
+	exec := pm.GetExecCommand()
+	hasBuild := wsApp.buildTarget || (wsApp.pkg != nil && pkg.HasScript("build"))
+	manifest := path.Join(wsApp.Path, "package.json")
+
+	switch {
+	case tool == WorkspaceToolTurbo:
+		return fmt.Sprintf("%s turbo run build --filter=%s", exec, wsApp.Name), "turbo.json"
+	case tool == WorkspaceToolMoon && wsApp.buildTarget:
+		return fmt.Sprintf("moon run %s:build", wsApp.ProjectID()), "moon build task"
+	case tool == WorkspaceToolNx && hasBuild:
+		return fmt.Sprintf("%s nx run %s:build", exec, wsApp.ProjectID()), "Nx build target"
+	}
+
+	if wsApp.pkg != nil && pkg.HasScript("build") {
+		source := manifest + " scripts.build"
+		switch pm.Name {
+		case PackageManagerPNPM:
+			return fmt.Sprintf("pnpm --filter \"%s...\" run build", wsApp.Name), source
+		case PackageManagerYarnBerry:
+			return fmt.Sprintf("yarn workspaces foreach -Rt --from %s run build", wsApp.Name), source
+		case PackageManagerYarn1:
+			return chainBuilds(deps, wsApp, func(name, dir string) string {
+				return fmt.Sprintf("yarn workspace %s run build", name)
+			}), source
+		case PackageManagerBun:
+			return chainBuilds(deps, wsApp, func(name, dir string) string {
+				return fmt.Sprintf("bun run --filter %s build", name)
+			}), source
+		default:
+			return chainBuilds(deps, wsApp, func(name, dir string) string {
+				return fmt.Sprintf("npm run build --workspace %s", dir)
+			}), source
+		}
+	}
+
+	// Framework default, run from the app directory
+	if cmd := fw.GetDefaultBuildCommand(pm); cmd != "" {
+		return fmt.Sprintf("cd %s && %s", wsApp.Path, cmd), fmt.Sprintf("%s default", fw.Name)
+	}
+	return "", ""
+}
+
+// chainBuilds runs the builds of the workspace dependencies that have a build script
+// before the app's own, for npm, Yarn 1 and Bun, whose filters don't follow the dependency graph
+// build returns the build command of a package from its name and directory
+func chainBuilds(deps []*workspacePackage, wsApp *WorkspaceApp, build func(name, dir string) string) string {
+	var steps []string
+	for _, dep := range deps {
+		if dep.Pkg.HasScript("build") {
+			steps = append(steps, build(dep.Name, dep.Path))
+		}
+	}
+	return strings.Join(append(steps, build(wsApp.Name, wsApp.Path)), " && ")
+}
+
+// workspaceStartCommand determines the start command, run from the app directory
+// Returns the command and the rule it came from
+func workspaceStartCommand(pm PackageManagerInfo, wsApp *WorkspaceApp, pkg *PackageJSON, fw FrameworkInfo) (string, string) {
+	// Nx integrated apps run their bundled output from the root
+	if wsApp.pkg == nil {
+		if outputPath := nxOutputPath(wsApp); outputPath != "" && fw.OutputType != OutputTypeStatic {
+			return fmt.Sprintf("node %s/main.js", outputPath), "Nx build target outputPath"
+		}
+		return "", ""
+	}
+	return determineStartCommand(pkg, path.Join(wsApp.Path, "package.json"), pm, fw)
+}
+
+// nxOutputPath returns the Nx build outputPath relative to the repository root
+func nxOutputPath(wsApp *WorkspaceApp) string {
+	if wsApp.outputPath == "" {
+		return ""
+	}
+	outputPath := strings.ReplaceAll(wsApp.outputPath, "{projectRoot}", wsApp.Path)
+	outputPath = strings.TrimPrefix(outputPath, "{workspaceRoot}/")
+	return path.Clean(outputPath)
+}
+
+// relativeTo returns target relative to base, both relative to the repository root
+func relativeTo(base, target string) string {
+	if base == "" {
+		return target
+	}
+	if strings.HasPrefix(target, base+"/") {
+		return strings.TrimPrefix(target, base+"/")
+	}
+	return strings.Repeat("../", strings.Count(base, "/")+1) + target
+}
+
+// turboVersion returns the Turborepo major version declared by the root package.json
+func turboVersion(root *PackageJSON) string {
+	version := cleanVersion(root.GetDependencyVersion("turbo"))
+	if major := strings.Split(version, ".")[0]; major != "" && strings.Trim(major, "0123456789") == "" {
+		return major
+	}
+	return "latest"
+}
@@ -0,0 +1,65 @@
+package php
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ComposerJSON represents the parts of composer.json that Coolpack cares about
+type ComposerJSON struct {
+	Name       string            `json:"name"`
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+	Config     struct {
+		Platform map[string]string `json:"platform"`
+	} `json:"config"`
+}
+
+// ComposerLock represents the parts of composer.lock that Coolpack cares about
+type ComposerLock struct {
+	Packages []ComposerPackage `json:"packages"`
+}
+
+// ComposerPackage is a resolved package in composer.lock
+type ComposerPackage struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+}
+
+// ParseComposerJSON parses composer.json from bytes
+func ParseComposerJSON(data []byte) (*ComposerJSON, error) {
+	var composer ComposerJSON
+	if err := json.Unmarshal(data, &composer); err != nil {
+		return nil, err
+	}
+	return &composer, nil
+}
+
+// ParseComposerLock parses composer.lock from bytes
+func ParseComposerLock(data []byte) (*ComposerLock, error) {
+	var lock ComposerLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// HasPackage checks if a package is required (production dependencies only)
+func (c *ComposerJSON) HasPackage(name string) bool {
+	_, ok := c.Require[name]
+	return ok
+}
+
+// PackageVersion returns the locked version of a package, falling back to the
+// constraint in composer.json
+func PackageVersion(composer *ComposerJSON, lock *ComposerLock, name string) string {
+	if lock != nil {
+		for _, pkg := range lock.Packages {
+			if pkg.Name == name {
+				return strings.TrimPrefix(pkg.Version, "v")
+			}
+		}
+	}
+	return strings.TrimLeft(composer.Require[name], "^~>=v ")
+}
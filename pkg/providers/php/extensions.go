@@ -0,0 +1,93 @@
+package php
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// builtinExtensions are compiled into the official PHP (and FrankenPHP) images
+var builtinExtensions = map[string]bool{
+	"core": true, "ctype": true, "curl": true, "date": true, "dom": true,
+	"fileinfo": true, "filter": true, "hash": true, "iconv": true, "json": true,
+	"libxml": true, "mbstring": true, "mysqlnd": true, "openssl": true, "pcre": true,
+	"pdo": true, "pdo_sqlite": true, "phar": true, "posix": true, "random": true,
+	"readline": true, "reflection": true, "session": true, "simplexml": true,
+	"sodium": true, "spl": true, "sqlite3": true, "standard": true, "tokenizer": true,
+	"xml": true, "xmlreader": true, "xmlwriter": true, "zlib": true,
+}
+
+// extensionAliases maps ext-* names to the names install-php-extensions expects
+var extensionAliases = map[string]string{
+	"zend-opcache": "opcache",
+	"zend opcache": "opcache",
+}
+
+// DefaultExtensions are installed in every image
+var DefaultExtensions = []string{"opcache"}
+
+// dbConnectionRegex matches DB_CONNECTION in Laravel's .env.example
+var dbConnectionRegex = regexp.MustCompile(`(?m)^DB_CONNECTION=(\w+)`)
+
+// DetectExtensions returns the PHP extensions to install, inferred from ext-*
+// requirements in composer.json and every locked package
+func DetectExtensions(ctx *app.Context, composer *ComposerJSON, lock *ComposerLock, fw FrameworkInfo) []string {
+	seen := make(map[string]bool)
+	var extensions []string
+
+	add := func(name string) {
+		name = strings.ToLower(name)
+		if alias, ok := extensionAliases[name]; ok {
+			name = alias
+		}
+		if builtinExtensions[name] || seen[name] {
+			return
+		}
+		seen[name] = true
+		extensions = append(extensions, name)
+	}
+
+	for _, ext := range DefaultExtensions {
+		add(ext)
+	}
+
+	var required []string
+	for dep := range composer.Require {
+		if strings.HasPrefix(dep, "ext-") {
+			required = append(required, strings.TrimPrefix(dep, "ext-"))
+		}
+	}
+	if lock != nil {
+		for _, pkg := range lock.Packages {
+			for dep := range pkg.Require {
+				if strings.HasPrefix(dep, "ext-") {
+					required = append(required, strings.TrimPrefix(dep, "ext-"))
+				}
+			}
+		}
+	}
+	sort.Strings(required)
+	for _, ext := range required {
+		add(ext)
+	}
+
+	// Laravel picks its database driver at runtime; use the one from .env.example
+	if fw.Name == FrameworkLaravel {
+		if data, err := ctx.ReadFile(".env.example"); err == nil {
+			if matches := dbConnectionRegex.FindSubmatch(data); len(matches) > 1 {
+				switch string(matches[1]) {
+				case "mysql", "mariadb":
+					add("pdo_mysql")
+				case "pgsql":
+					add("pdo_pgsql")
+				case "sqlsrv":
+					add("pdo_sqlsrv")
+				}
+			}
+		}
+	}
+
+	return extensions
+}
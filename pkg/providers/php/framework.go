@@ -0,0 +1,55 @@
+package php
+
+import (
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Framework represents a detected PHP framework
+type Framework string
+
+const (
+	FrameworkNone    Framework = ""
+	FrameworkLaravel Framework = "laravel"
+	FrameworkSymfony Framework = "symfony"
+)
+
+// FrameworkInfo contains information about the detected framework
+type FrameworkInfo struct {
+	Name    Framework
+	Version string
+
+	// HasOctane is true if Laravel Octane is installed
+	HasOctane bool
+}
+
+// DetectFramework detects the framework used by the project
+func DetectFramework(ctx *app.Context, composer *ComposerJSON, lock *ComposerLock) FrameworkInfo {
+	info := FrameworkInfo{
+		Name: FrameworkNone,
+	}
+
+	// Laravel: the artisan console script
+	if ctx.HasFile("artisan") {
+		info.Name = FrameworkLaravel
+		info.Version = PackageVersion(composer, lock, "laravel/framework")
+		info.HasOctane = composer.HasPackage("laravel/octane")
+		return info
+	}
+
+	// Symfony: symfony.lock (Symfony Flex) or the framework bundle
+	if ctx.HasFile("symfony.lock") || composer.HasPackage("symfony/framework-bundle") {
+		info.Name = FrameworkSymfony
+		info.Version = PackageVersion(composer, lock, "symfony/framework-bundle")
+		return info
+	}
+
+	return info
+}
+
+// DocumentRoot returns the web root relative to the application directory
+func (f FrameworkInfo) DocumentRoot(ctx *app.Context) string {
+	if f.Name != FrameworkNone || ctx.HasFile("public/index.php") {
+		return "public"
+	}
+	return "."
+}
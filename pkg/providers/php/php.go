@@ -0,0 +1,187 @@
+package php
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+)
+
+// Provider is the PHP provider implementation
+type Provider struct{}
+
+// New creates a new PHP provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "php"
+}
+
+// Detect checks if the application is a PHP (Composer) project
+// Laravel ships a package.json for Vite, so a PHP entry point ranks as an application.
+// A composer.json next to package.json without one is only a weak match
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("composer.json") {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found composer.json")
+	for _, f := range []string{"artisan", "symfony.lock", "public/index.php", "index.php"} {
+		if ctx.HasFile(f) {
+			detection.Raise(app.ConfidenceApp, "found PHP entry point "+f)
+			return detection, nil
+		}
+	}
+	if ctx.HasFile("package.json") {
+		return app.Detected(app.ConfidenceWeak, "found composer.json", "no PHP entry point next to package.json"), nil
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the PHP application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	data, err := ctx.ReadFile("composer.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read composer.json: %w", err)
+	}
+	composer, err := ParseComposerJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse composer.json: %w", err)
+	}
+
+	var lock *ComposerLock
+	if data, err := ctx.ReadFile("composer.lock"); err == nil {
+		lock, _ = ParseComposerLock(data)
+	}
+
+	// Detect PHP version
+	phpVersion := DetectPHPVersion(ctx, composer)
+
+	// Detect framework
+	fwInfo := DetectFramework(ctx, composer, lock)
+
+	plan := &app.Plan{
+		Provider:        "php",
+		Language:        "php",
+		LanguageVersion: phpVersion,
+		PackageManager:  "composer",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	// Add framework info
+	if fwInfo.Name != FrameworkNone {
+		plan.Framework = string(fwInfo.Name)
+		plan.FrameworkVersion = fwInfo.Version
+	}
+
+	// Runner: FrankenPHP (default) or php-fpm behind Caddy
+	// FrankenPHP images start at PHP 8.2, older versions fall back to php-fpm
+	runner := "frankenphp"
+	if olderThan(phpVersion, 8, 2) {
+		runner = "fpm"
+	}
+	if r := ctx.Env["COOLPACK_PHP_RUNNER"]; r != "" {
+		runner = r
+	}
+	if runner != "frankenphp" && runner != "fpm" {
+		return nil, fmt.Errorf("unsupported PHP runner %q (expected frankenphp or fpm)", runner)
+	}
+	plan.Runtime.Runner = runner
+
+	// Install dependencies without the source tree, then generate the autoloader
+	// (and run framework scripts) once the source is copied
+	plan.InstallCommand = "composer install --no-dev --no-interaction --prefer-dist --no-autoloader --no-scripts"
+	buildSteps := []string{"composer dump-autoload --optimize --no-dev"}
+	if fwInfo.Name == FrameworkSymfony {
+		buildSteps = append(buildSteps, "composer run-script --no-dev post-install-cmd")
+	}
+
+	// Frontend assets (Laravel Vite, Symfony Encore) are built in the same stage,
+	// since Tailwind and Livewire read views and vendor/ during the build
+	if ctx.HasFile("package.json") {
+		if pkgData, err := ctx.ReadFile("package.json"); err == nil {
+			if pkg, err := node.ParsePackageJSON(pkgData); err == nil && pkg.HasScript("build") {
+				pmInfo := node.DetectPackageManager(ctx, pkg)
+				nodeVersion := node.DetectNodeVersion(ctx, pkg)
+				plan.Tools.NodeVersion = nodeVersion.Version
+				plan.SetSource("tools.node_version", nodeVersion.Source)
+				plan.Tools.NodePackageManager = string(pmInfo.Name)
+				buildSteps = append(buildSteps,
+					pmInfo.GetInstallCommand(),
+					pmInfo.GetRunCommand()+" build",
+					"rm -rf node_modules",
+				)
+			}
+		}
+	}
+	plan.BuildCommand = strings.Join(buildSteps, " && ")
+
+	plan.StartCommand = determineStartCommand(fwInfo, runner, fwInfo.DocumentRoot(ctx))
+	plan.PHP.DocumentRoot = fwInfo.DocumentRoot(ctx)
+
+	// Composer needs git and unzip to fetch packages
+	plan.Packages.Apt = []string{"git", "unzip"}
+
+	// PHP extensions from ext-* requirements
+	plan.PHP.Extensions = DetectExtensions(ctx, composer, lock, fwInfo)
+
+	plan.Runtime.OutputType = "server"
+
+	if composer.Name != "" {
+		plan.Project.Name = composer.Name
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// determineStartCommand determines the start command for the runner
+// All commands listen on $PORT
+func determineStartCommand(fw FrameworkInfo, runner string, documentRoot string) string {
+	var server string
+	switch {
+	case runner == "fpm":
+		// php-fpm daemonizes, Caddy stays in the foreground
+		server = "php-fpm -D && exec caddy run --config /etc/caddy/Caddyfile --adapter caddyfile"
+	case fw.Name == FrameworkLaravel && fw.HasOctane:
+		server = "exec php artisan octane:frankenphp --host=0.0.0.0 --port=$PORT"
+	default:
+		server = fmt.Sprintf("exec frankenphp php-server --root %s/ --listen :$PORT", documentRoot)
+	}
+
+	// Laravel caches config, routes and views from the runtime environment
+	if fw.Name == FrameworkLaravel {
+		return "php artisan optimize && " + server
+	}
+	return server
+}
+
+// olderThan reports whether a major.minor version is older than major.minor
+func olderThan(version string, major, minor int) bool {
+	matches := versionRegex.FindStringSubmatch(version)
+	if len(matches) < 3 {
+		return false
+	}
+	vMajor, _ := strconv.Atoi(matches[1])
+	vMinor, _ := strconv.Atoi(matches[2])
+	return vMajor < major || (vMajor == major && vMinor < minor)
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range []string{"composer.json", "composer.lock", "artisan", "symfony.lock", "package.json", "public/index.php", "index.php", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
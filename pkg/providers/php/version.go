@@ -0,0 +1,85 @@
+package php
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultPHPVersion = "8.4"
+
+// versionRegex extracts a major.minor PHP version from a string
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// DetectPHPVersion detects the PHP version to use
+// Priority:
+// 1. COOLPACK_PHP_VERSION environment variable
+// 2. config.platform.php in composer.json
+// 3. require.php constraint in composer.json
+// 4. .tool-versions file (asdf)
+// 5. Default to 8.4
+func DetectPHPVersion(ctx *app.Context, composer *ComposerJSON) string {
+	// 1. Check COOLPACK_PHP_VERSION env var
+	if v := ctx.Env["COOLPACK_PHP_VERSION"]; v != "" {
+		return strings.TrimSpace(v)
+	}
+
+	// 2. Check config.platform.php (the version Composer resolves dependencies for)
+	if v := extractMajorMinor(composer.Config.Platform["php"]); v != "" {
+		return v
+	}
+
+	// 3. Check require.php
+	if v := parseVersionConstraint(composer.Require["php"]); v != "" {
+		return v
+	}
+
+	// 4. Check .tool-versions file (asdf format)
+	if v := extractMajorMinor(ctx.ReadToolVersion("php")); v != "" {
+		return v
+	}
+
+	// 5. Default
+	return DefaultPHPVersion
+}
+
+// parseVersionConstraint picks a major.minor version from a Composer constraint
+// Examples: "^8.2", ">=8.1", "~8.3.0", "8.2.*", "^8.1 || ^8.2", ">=8.1 <8.4"
+// Each alternative contributes its lower bound and the highest one is used,
+// so "^7.4|^8.0" resolves to 8.0 rather than an end-of-life release
+func parseVersionConstraint(constraint string) string {
+	best := ""
+	bestMajor, bestMinor := -1, -1
+
+	for _, alternative := range strings.Split(strings.ReplaceAll(constraint, "||", "|"), "|") {
+		for _, part := range strings.Fields(strings.ReplaceAll(alternative, ",", " ")) {
+			if strings.HasPrefix(part, "<") || strings.HasPrefix(part, "!=") {
+				continue
+			}
+			matches := versionRegex.FindStringSubmatch(part)
+			if len(matches) < 3 {
+				continue
+			}
+			major, _ := strconv.Atoi(matches[1])
+			minor, _ := strconv.Atoi(matches[2])
+			if major > bestMajor || (major == bestMajor && minor > bestMinor) {
+				best = matches[1] + "." + matches[2]
+				bestMajor, bestMinor = major, minor
+			}
+			break
+		}
+	}
+
+	return best
+}
+
+// extractMajorMinor returns the major.minor part of a version string
+func extractMajorMinor(v string) string {
+	matches := versionRegex.FindStringSubmatch(v)
+	if len(matches) > 2 {
+		return matches[1] + "." + matches[2]
+	}
+	return ""
+}
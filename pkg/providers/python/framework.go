@@ -0,0 +1,299 @@
+package python
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Framework represents a detected Python web framework
+type Framework string
+
+const (
+	FrameworkNone      Framework = ""
+	FrameworkDjango    Framework = "django"
+	FrameworkFlask     Framework = "flask"
+	FrameworkFastAPI   Framework = "fastapi"
+	FrameworkStreamlit Framework = "streamlit"
+)
+
+// FrameworkInfo contains information about the detected framework
+type FrameworkInfo struct {
+	Name    Framework
+	Version string
+
+	// AppModule is the import path of the WSGI/ASGI application (e.g., "mysite.wsgi:application")
+	// For Streamlit it is the script path instead (e.g., "streamlit_app.py")
+	AppModule string
+
+	// HasStaticRoot is true if Django's settings define STATIC_ROOT (collectstatic is possible)
+	HasStaticRoot bool
+}
+
+var (
+	// djangoSettingsRegex matches DJANGO_SETTINGS_MODULE in manage.py
+	djangoSettingsRegex = regexp.MustCompile(`DJANGO_SETTINGS_MODULE["']\s*,\s*["']([\w.]+)["']`)
+
+	// flaskAppRegex matches a Flask application object (app = Flask(__name__))
+	flaskAppRegex = regexp.MustCompile(`(?m)^(\w+)\s*(?::\s*\w+\s*)?=\s*(?:flask\.)?Flask\(`)
+
+	// flaskFactoryRegex matches a Flask application factory (def create_app(...))
+	flaskFactoryRegex = regexp.MustCompile(`(?m)^def\s+(create_app|make_app)\s*\(`)
+
+	// fastAPIAppRegex matches a FastAPI application object (app = FastAPI())
+	fastAPIAppRegex = regexp.MustCompile(`(?m)^(\w+)\s*(?::\s*\w+\s*)?=\s*(?:fastapi\.)?FastAPI\(`)
+)
+
+// appCandidates are the files searched for WSGI/ASGI application objects, in priority order
+var appCandidates = []string{
+	"app.py",
+	"main.py",
+	"wsgi.py",
+	"asgi.py",
+	"application.py",
+	"server.py",
+	"api.py",
+	"app/__init__.py",
+	"app/main.py",
+}
+
+// streamlitCandidates are common Streamlit entry scripts, in priority order
+var streamlitCandidates = []string{
+	"streamlit_app.py",
+	"app.py",
+	"main.py",
+	"Home.py",
+	"Main.py",
+}
+
+// DetectFramework detects the web framework used by the project
+func DetectFramework(ctx *app.Context, project *Project) FrameworkInfo {
+	info := FrameworkInfo{
+		Name: FrameworkNone,
+	}
+
+	// Django: manage.py plus a settings module
+	if ctx.HasFile("manage.py") && (project.HasDependency("django") || hasDjangoSettings(ctx)) {
+		info.Name = FrameworkDjango
+		info.Version = CleanVersion(project.GetDependencyVersion("django"))
+		settingsModule := detectDjangoSettingsModule(ctx)
+		if settingsModule != "" {
+			pkg := djangoProjectPackage(settingsModule)
+			info.AppModule = pkg + ".wsgi:application"
+			// Prefer ASGI when the project ships uvicorn but not gunicorn
+			asgiFile := path.Join(strings.ReplaceAll(pkg, ".", "/"), "asgi.py")
+			if ctx.HasFile(asgiFile) && project.HasDependency("uvicorn") && !project.HasDependency("gunicorn") {
+				info.AppModule = pkg + ".asgi:application"
+			}
+			info.HasStaticRoot = djangoHasStaticRoot(ctx, settingsModule)
+		}
+		return info
+	}
+
+	// FastAPI: dependency plus an ASGI app object
+	if project.HasDependency("fastapi") {
+		info.Name = FrameworkFastAPI
+		info.Version = CleanVersion(project.GetDependencyVersion("fastapi"))
+		info.AppModule = findAppObject(ctx, fastAPIAppRegex, nil)
+		return info
+	}
+
+	// Flask: dependency plus a WSGI app object or factory
+	if project.HasDependency("flask") {
+		info.Name = FrameworkFlask
+		info.Version = CleanVersion(project.GetDependencyVersion("flask"))
+		info.AppModule = findAppObject(ctx, flaskAppRegex, flaskFactoryRegex)
+		return info
+	}
+
+	// Streamlit: dependency plus an entry script
+	if project.HasDependency("streamlit") {
+		info.Name = FrameworkStreamlit
+		info.Version = CleanVersion(project.GetDependencyVersion("streamlit"))
+		for _, candidate := range streamlitCandidates {
+			if ctx.HasFile(candidate) {
+				info.AppModule = candidate
+				break
+			}
+		}
+		return info
+	}
+
+	return info
+}
+
+// hasDjangoSettings checks for a settings.py (or settings package) one level deep
+func hasDjangoSettings(ctx *app.Context) bool {
+	for _, pattern := range []string{"*/settings.py", "*/settings/__init__.py"} {
+		if matches, err := ctx.ListFiles(pattern); err == nil && len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDjangoSettingsModule returns the settings module (e.g., "mysite.settings")
+// from manage.py, falling back to the first */settings.py found
+func detectDjangoSettingsModule(ctx *app.Context) string {
+	if data, err := ctx.ReadFile("manage.py"); err == nil {
+		if matches := djangoSettingsRegex.FindSubmatch(data); len(matches) > 1 {
+			return string(matches[1])
+		}
+	}
+
+	for _, pattern := range []string{"*/settings.py", "*/settings/__init__.py"} {
+		matches, err := ctx.ListFiles(pattern)
+		if err != nil || len(matches) == 0 {
+			continue
+		}
+		dir := path.Dir(strings.TrimSuffix(matches[0], "/__init__.py"))
+		return strings.ReplaceAll(dir, "/", ".") + ".settings"
+	}
+
+	return ""
+}
+
+// djangoProjectPackage returns the package containing the settings module
+// "mysite.settings" -> "mysite", "config.settings.production" -> "config"
+func djangoProjectPackage(settingsModule string) string {
+	idx := strings.Index(settingsModule, ".settings")
+	if idx == -1 {
+		return settingsModule
+	}
+	return settingsModule[:idx]
+}
+
+// djangoHasStaticRoot checks if the settings module configures STATIC_ROOT
+func djangoHasStaticRoot(ctx *app.Context, settingsModule string) bool {
+	base := strings.ReplaceAll(settingsModule, ".", "/")
+	for _, candidate := range []string{base + ".py", base + "/__init__.py", base + "/base.py", base + "/production.py"} {
+		data, err := ctx.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(string(data), "STATIC_ROOT") {
+			return true
+		}
+	}
+	return false
+}
+
+// findAppObject searches candidate files for an application object or factory
+// Returns a module reference such as "main:app" or "app:create_app()"
+func findAppObject(ctx *app.Context, appRegex, factoryRegex *regexp.Regexp) string {
+	for _, candidate := range appCandidates {
+		data, err := ctx.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+
+		module := pythonModuleName(candidate)
+		if matches := appRegex.FindSubmatch(data); len(matches) > 1 {
+			return fmt.Sprintf("%s:%s", module, matches[1])
+		}
+		if factoryRegex != nil {
+			if matches := factoryRegex.FindSubmatch(data); len(matches) > 1 {
+				return fmt.Sprintf("%s:%s()", module, matches[1])
+			}
+		}
+	}
+	return ""
+}
+
+// pythonModuleName converts a file path to an importable module name
+// "app/main.py" -> "app.main", "app/__init__.py" -> "app"
+func pythonModuleName(file string) string {
+	module := strings.TrimSuffix(file, ".py")
+	module = strings.TrimSuffix(module, "/__init__")
+	return strings.ReplaceAll(module, "/", ".")
+}
+
+// CleanVersion strips specifier operators from a version ("==4.2.1" -> "4.2.1")
+func CleanVersion(v string) string {
+	v = strings.TrimSpace(strings.Split(v, ",")[0])
+	return strings.TrimLeft(v, "=~^<>! ")
+}
+
+// GetDefaultBuildCommand returns the default build command for a framework
+func (f FrameworkInfo) GetDefaultBuildCommand() string {
+	switch f.Name {
+	case FrameworkDjango:
+		if f.HasStaticRoot {
+			return "python manage.py collectstatic --noinput"
+		}
+	}
+	return ""
+}
+
+// GetDefaultStartCommand returns the default start command for a framework
+// All commands bind to 0.0.0.0:$PORT
+func (f FrameworkInfo) GetDefaultStartCommand() string {
+	switch f.Name {
+	case FrameworkDjango:
+		if f.AppModule == "" {
+			return "python manage.py runserver 0.0.0.0:$PORT"
+		}
+		if strings.Contains(f.AppModule, ".asgi:") {
+			return fmt.Sprintf("uvicorn %s --host 0.0.0.0 --port $PORT", f.AppModule)
+		}
+		return fmt.Sprintf("gunicorn %s --bind 0.0.0.0:$PORT", f.AppModule)
+	case FrameworkFlask:
+		if f.AppModule == "" {
+			return ""
+		}
+		return fmt.Sprintf("gunicorn %s --bind 0.0.0.0:$PORT", quoteFactory(f.AppModule))
+	case FrameworkFastAPI:
+		if f.AppModule == "" {
+			return ""
+		}
+		return fmt.Sprintf("uvicorn %s --host 0.0.0.0 --port $PORT", f.AppModule)
+	case FrameworkStreamlit:
+		script := f.AppModule
+		if script == "" {
+			script = "app.py"
+		}
+		return fmt.Sprintf("streamlit run %s --server.port $PORT --server.address 0.0.0.0 --server.headless true", script)
+	}
+	return ""
+}
+
+// GetServerPackages returns application server packages the start command needs
+// that are not declared as project dependencies
+func (f FrameworkInfo) GetServerPackages(project *Project) []string {
+	var server string
+	switch f.Name {
+	case FrameworkDjango:
+		if f.AppModule == "" {
+			return nil
+		}
+		if strings.Contains(f.AppModule, ".asgi:") {
+			server = "uvicorn"
+		} else {
+			server = "gunicorn"
+		}
+	case FrameworkFlask:
+		if f.AppModule != "" {
+			server = "gunicorn"
+		}
+	case FrameworkFastAPI:
+		if f.AppModule != "" {
+			server = "uvicorn"
+		}
+	}
+
+	if server == "" || project.HasDependency(server) {
+		return nil
+	}
+	return []string{server}
+}
+
+// quoteFactory quotes an application factory call for the shell ("app:create_app()")
+func quoteFactory(module string) string {
+	if strings.HasSuffix(module, "()") {
+		return "'" + module + "'"
+	}
+	return module
+}
@@ -0,0 +1,170 @@
+package python
+
+import (
+	"regexp"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// PackageManager represents a Python package installer
+type PackageManager string
+
+const (
+	PackageManagerPip    PackageManager = "pip"
+	PackageManagerPoetry PackageManager = "poetry"
+	PackageManagerUV     PackageManager = "uv"
+	PackageManagerPipenv PackageManager = "pipenv"
+)
+
+// PackageManagerInfo contains information about the detected package manager
+type PackageManagerInfo struct {
+	Name    PackageManager
+	Version string
+
+	// HasLockFile is true if the package manager's lock file is present
+	HasLockFile bool
+
+	// RequirementsFiles are the requirements files pip installs from (pip only)
+	// The first entry is the top-level file, the rest are -r includes
+	RequirementsFiles []string
+}
+
+// poetryVersionRegex matches the generator comment at the top of poetry.lock
+var poetryVersionRegex = regexp.MustCompile(`@generated by Poetry ([0-9][0-9.]*)`)
+
+// DetectPackageManager detects the package manager used by the project
+// Detection priority:
+// 1. Lock files (uv.lock, poetry.lock, Pipfile.lock)
+// 2. [tool.uv] / [tool.poetry] sections in pyproject.toml
+// 3. Pipfile
+// 4. Default to pip
+func DetectPackageManager(ctx *app.Context, project *Project) PackageManagerInfo {
+	info := PackageManagerInfo{
+		Name:              PackageManagerPip,
+		RequirementsFiles: project.RequirementsFiles,
+	}
+
+	// 1. Check lock files
+	if ctx.HasFile("uv.lock") {
+		info.Name = PackageManagerUV
+		info.HasLockFile = true
+		return info
+	}
+
+	if ctx.HasFile("poetry.lock") {
+		info.Name = PackageManagerPoetry
+		info.HasLockFile = true
+		info.Version = detectPoetryVersion(ctx)
+		return info
+	}
+
+	if ctx.HasFile("Pipfile.lock") {
+		info.Name = PackageManagerPipenv
+		info.HasLockFile = true
+		return info
+	}
+
+	// 2. Check pyproject.toml tool sections
+	if project.PyProject != nil {
+		if project.PyProject.HasTool("uv") {
+			info.Name = PackageManagerUV
+			return info
+		}
+		if project.PyProject.HasTool("poetry") {
+			info.Name = PackageManagerPoetry
+			return info
+		}
+	}
+
+	// 3. Check Pipfile
+	if project.Pipfile != nil {
+		info.Name = PackageManagerPipenv
+		return info
+	}
+
+	// 4. Default to pip
+	return info
+}
+
+// detectPoetryVersion extracts the Poetry version that generated poetry.lock
+func detectPoetryVersion(ctx *app.Context) string {
+	data, err := ctx.ReadFile("poetry.lock")
+	if err != nil {
+		return ""
+	}
+	matches := poetryVersionRegex.FindSubmatch(data)
+	if len(matches) > 1 {
+		return string(matches[1])
+	}
+	return ""
+}
+
+// GetInstallCommand returns the dependency install command for the package manager
+func (pm PackageManagerInfo) GetInstallCommand() string {
+	switch pm.Name {
+	case PackageManagerUV:
+		if pm.HasLockFile {
+			return "uv sync --frozen --no-dev --no-install-project"
+		}
+		return "uv sync --no-dev --no-install-project"
+	case PackageManagerPoetry:
+		return "poetry install --only main --no-root --no-interaction"
+	case PackageManagerPipenv:
+		if pm.HasLockFile {
+			return "pipenv sync"
+		}
+		return "pipenv install --skip-lock"
+	default:
+		if len(pm.RequirementsFiles) > 0 {
+			return "pip install -r " + pm.RequirementsFiles[0]
+		}
+		return "pip install ."
+	}
+}
+
+// GetProjectInstallCommand returns the command that installs the project itself once
+// its source is copied, or "" if the install command already does
+func (pm PackageManagerInfo) GetProjectInstallCommand() string {
+	switch pm.Name {
+	case PackageManagerUV:
+		// Inexact keeps the application servers installed next to the dependencies
+		if pm.HasLockFile {
+			return "uv sync --frozen --no-dev --inexact"
+		}
+		return "uv sync --no-dev --inexact"
+	case PackageManagerPoetry:
+		return "poetry install --only main --no-interaction"
+	default:
+		return ""
+	}
+}
+
+// GetDependencyFiles returns the files needed to install dependencies before
+// the rest of the source is copied. An empty list means the install step
+// needs the full source tree (e.g., pip install .)
+func (pm PackageManagerInfo) GetDependencyFiles() []string {
+	switch pm.Name {
+	case PackageManagerUV:
+		return []string{"pyproject.toml", "uv.lock*"}
+	case PackageManagerPoetry:
+		return []string{"pyproject.toml", "poetry.lock*"}
+	case PackageManagerPipenv:
+		return []string{"Pipfile", "Pipfile.lock*"}
+	default:
+		return pm.RequirementsFiles
+	}
+}
+
+// GetLockFile returns the lock file name for the package manager
+func (pm PackageManagerInfo) GetLockFile() string {
+	switch pm.Name {
+	case PackageManagerUV:
+		return "uv.lock"
+	case PackageManagerPoetry:
+		return "poetry.lock"
+	case PackageManagerPipenv:
+		return "Pipfile.lock"
+	default:
+		return "requirements.txt"
+	}
+}
@@ -0,0 +1,247 @@
+package python
+
+import (
+	"bufio"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// PyProject represents the parts of pyproject.toml that Coolpack cares about
+type PyProject struct {
+	Project struct {
+		Name           string            `toml:"name"`
+		Version        string            `toml:"version"`
+		RequiresPython string            `toml:"requires-python"`
+		Dependencies   []string          `toml:"dependencies"`
+		Scripts        map[string]string `toml:"scripts"`
+	} `toml:"project"`
+	Tool struct {
+		Poetry struct {
+			Name         string                 `toml:"name"`
+			Dependencies map[string]interface{} `toml:"dependencies"`
+			Scripts      map[string]interface{} `toml:"scripts"`
+			Packages     []interface{}          `toml:"packages"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+
+	meta toml.MetaData
+}
+
+// Pipfile represents the parts of a Pipfile that Coolpack cares about
+type Pipfile struct {
+	Packages map[string]interface{} `toml:"packages"`
+	Requires struct {
+		PythonVersion     string `toml:"python_version"`
+		PythonFullVersion string `toml:"python_full_version"`
+	} `toml:"requires"`
+}
+
+// Project aggregates everything known about a Python project's dependencies
+type Project struct {
+	PyProject *PyProject
+	Pipfile   *Pipfile
+
+	// RequirementsFiles lists requirements.txt and any files it includes with -r
+	RequirementsFiles []string
+
+	// dependencies maps normalized names of runtime dependencies to their version specifiers
+	dependencies map[string]string
+}
+
+// requirementRegex matches the distribution name, optional extras and
+// version specifier of a PEP 508 requirement string
+var requirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;@]*)`)
+
+// LoadProject reads pyproject.toml, Pipfile and requirements.txt from the context
+func LoadProject(ctx *app.Context) (*Project, error) {
+	project := &Project{
+		dependencies: make(map[string]string),
+	}
+
+	if ctx.HasFile("pyproject.toml") {
+		data, err := ctx.ReadFile("pyproject.toml")
+		if err != nil {
+			return nil, err
+		}
+		pyproject, err := ParsePyProject(data)
+		if err != nil {
+			return nil, err
+		}
+		project.PyProject = pyproject
+
+		for _, dep := range pyproject.Project.Dependencies {
+			project.addRequirement(dep)
+		}
+		for name, spec := range pyproject.Tool.Poetry.Dependencies {
+			if name != "python" {
+				project.addDependency(name, tableVersion(spec))
+			}
+		}
+	}
+
+	if ctx.HasFile("Pipfile") {
+		data, err := ctx.ReadFile("Pipfile")
+		if err != nil {
+			return nil, err
+		}
+		var pipfile Pipfile
+		if _, err := toml.Decode(string(data), &pipfile); err != nil {
+			return nil, err
+		}
+		project.Pipfile = &pipfile
+
+		for name, spec := range pipfile.Packages {
+			project.addDependency(name, tableVersion(spec))
+		}
+	}
+
+	if ctx.HasFile("requirements.txt") {
+		project.readRequirements(ctx, "requirements.txt", make(map[string]bool))
+	}
+
+	return project, nil
+}
+
+// ParsePyProject parses a pyproject.toml file from bytes
+func ParsePyProject(data []byte) (*PyProject, error) {
+	var pyproject PyProject
+	meta, err := toml.Decode(string(data), &pyproject)
+	if err != nil {
+		return nil, err
+	}
+	pyproject.meta = meta
+	return &pyproject, nil
+}
+
+// HasTool checks if a [tool.<name>] table is defined in pyproject.toml
+func (p *PyProject) HasTool(name string) bool {
+	return p.meta.IsDefined("tool", name)
+}
+
+// readRequirements parses a requirements file, following -r includes
+func (p *Project) readRequirements(ctx *app.Context, name string, visited map[string]bool) {
+	if visited[name] {
+		return
+	}
+	visited[name] = true
+
+	data, err := ctx.ReadFile(name)
+	if err != nil {
+		return
+	}
+	p.RequirementsFiles = append(p.RequirementsFiles, name)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Strip inline comments
+		if idx := strings.Index(line, " #"); idx != -1 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Nested requirements files (-r other.txt / --requirement other.txt)
+		if strings.HasPrefix(line, "-r ") || strings.HasPrefix(line, "--requirement ") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 {
+				include := path.Join(path.Dir(name), fields[1])
+				p.readRequirements(ctx, include, visited)
+			}
+			continue
+		}
+
+		// Skip other options (-e, --index-url, -c, ...)
+		if strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		p.addRequirement(line)
+	}
+}
+
+// addRequirement adds a dependency from a PEP 508 requirement string
+func (p *Project) addRequirement(requirement string) {
+	matches := requirementRegex.FindStringSubmatch(strings.TrimSpace(requirement))
+	if len(matches) > 2 {
+		p.addDependency(matches[1], strings.TrimSpace(matches[2]))
+	}
+}
+
+// addDependency adds a dependency by name with its version specifier
+func (p *Project) addDependency(name string, spec string) {
+	p.dependencies[normalizeName(name)] = spec
+}
+
+// HasDependency checks if a dependency is declared in any of the project files
+func (p *Project) HasDependency(name string) bool {
+	_, ok := p.dependencies[normalizeName(name)]
+	return ok
+}
+
+// GetDependencyVersion returns the version specifier of a dependency (e.g., "==4.2.1")
+func (p *Project) GetDependencyVersion(name string) string {
+	return p.dependencies[normalizeName(name)]
+}
+
+// tableVersion extracts the version from a Poetry/Pipfile dependency value,
+// which is either a string ("^4.2") or a table ({ version = "^4.2", extras = [...] })
+func tableVersion(spec interface{}) string {
+	switch v := spec.(type) {
+	case string:
+		if v == "*" {
+			return ""
+		}
+		return v
+	case map[string]interface{}:
+		if version, ok := v["version"].(string); ok && version != "*" {
+			return version
+		}
+	}
+	return ""
+}
+
+// Name returns the project name from pyproject.toml
+func (p *Project) Name() string {
+	if p.PyProject == nil {
+		return ""
+	}
+	if p.PyProject.Project.Name != "" {
+		return p.PyProject.Project.Name
+	}
+	return p.PyProject.Tool.Poetry.Name
+}
+
+// InstallReason returns why the project itself has to be installed into the virtual
+// environment after its source is copied, or "" if running it from /app is enough
+// Entry points and src layout packages are only available once installed
+func (p *Project) InstallReason(ctx *app.Context) string {
+	if p.PyProject == nil {
+		return ""
+	}
+	switch {
+	case len(p.PyProject.Project.Scripts) > 0:
+		return "[project.scripts] in pyproject.toml"
+	case len(p.PyProject.Tool.Poetry.Scripts) > 0:
+		return "[tool.poetry.scripts] in pyproject.toml"
+	case len(p.PyProject.Tool.Poetry.Packages) > 0:
+		return "packages in [tool.poetry]"
+	case ctx.HasMatch("src/*/__init__.py"):
+		return "src layout"
+	}
+	return ""
+}
+
+// normalizeName normalizes a distribution name as described in PEP 503
+func normalizeName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "_", "-")
+	name = strings.ReplaceAll(name, ".", "-")
+	return name
+}
@@ -0,0 +1,177 @@
+package python
+
+import (
+	"fmt"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Provider is the Python provider implementation
+type Provider struct{}
+
+// New creates a new Python provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "python"
+}
+
+// detectFiles are the files that identify a Python project
+var detectFiles = []string{
+	"requirements.txt",
+	"pyproject.toml",
+	"poetry.lock",
+	"uv.lock",
+	"Pipfile",
+	"Pipfile.lock",
+}
+
+// Detect checks if the application is a Python project
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	detection := app.NoMatch()
+	for _, f := range detectFiles {
+		if ctx.HasFile(f) {
+			detection = app.Detected(app.ConfidenceManifest, "found "+f)
+			break
+		}
+	}
+	if !detection.Matched() {
+		return detection, nil
+	}
+
+	project, err := LoadProject(ctx)
+	if err != nil {
+		return detection, err
+	}
+	if fw := DetectFramework(ctx, project); fw.Name != FrameworkNone {
+		detection.Raise(app.ConfidenceApp, fmt.Sprintf("uses %s", fw.Name))
+	} else if cmd := determineStartCommand(ctx, fw); cmd != "" {
+		detection.Raise(app.ConfidenceApp, "entry point for "+cmd)
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the Python application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	project, err := LoadProject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Python project files: %w", err)
+	}
+
+	// Detect package manager
+	pmInfo := DetectPackageManager(ctx, project)
+
+	// Detect Python version
+	pythonVersion := DetectPythonVersion(ctx, project)
+
+	// Detect framework
+	fwInfo := DetectFramework(ctx, project)
+
+	plan := &app.Plan{
+		Provider:              "python",
+		Language:              "python",
+		LanguageVersion:       pythonVersion.Version,
+		PackageManager:        string(pmInfo.Name),
+		PackageManagerVersion: pmInfo.Version,
+		DetectedFiles:         detectRelevantFiles(ctx),
+	}
+	plan.SetSource("language_version", pythonVersion.Source)
+
+	// Add framework info
+	if fwInfo.Name != FrameworkNone {
+		plan.Framework = string(fwInfo.Name)
+		plan.FrameworkVersion = fwInfo.Version
+	}
+
+	// Determine install command
+	plan.InstallCommand = pmInfo.GetInstallCommand()
+
+	// Determine build command
+	plan.BuildCommand = fwInfo.GetDefaultBuildCommand()
+
+	// Determine start command
+	plan.StartCommand = determineStartCommand(ctx, fwInfo)
+
+	// Application servers used by the start command but not declared as dependencies
+	if serverPackages := fwInfo.GetServerPackages(project); len(serverPackages) > 0 {
+		plan.Python.ServerPackages = serverPackages
+	}
+
+	// Files needed to install dependencies before copying the source
+	if files := pmInfo.GetDependencyFiles(); len(files) > 0 {
+		plan.Python.DependencyFiles = files
+	}
+
+	// The install above skips the project itself, which only its source can install
+	if reason := project.InstallReason(ctx); reason != "" {
+		if cmd := pmInfo.GetProjectInstallCommand(); cmd != "" {
+			plan.Python.ProjectInstallCommand = cmd
+			plan.SetSource("python.project_install_command", reason)
+		}
+	}
+
+	// Python apps always run behind an application server
+	plan.Runtime.OutputType = "server"
+
+	if name := project.Name(); name != "" {
+		plan.Project.Name = name
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// determineStartCommand determines the start command to use
+func determineStartCommand(ctx *app.Context, fw FrameworkInfo) string {
+	// Use framework-specific defaults
+	if cmd := fw.GetDefaultStartCommand(); cmd != "" {
+		return cmd
+	}
+
+	// Check for common entry points
+	entryPoints := []string{"main.py", "app.py", "server.py", "run.py"}
+	for _, ep := range entryPoints {
+		if ctx.HasFile(ep) {
+			return fmt.Sprintf("python %s", ep)
+		}
+	}
+
+	return ""
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+
+	// Project and lock files
+	for _, f := range detectFiles {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+
+	// Framework entry points
+	entryFiles := []string{"manage.py", "wsgi.py", "asgi.py", "streamlit_app.py"}
+	for _, f := range entryFiles {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+
+	// Version files
+	versionFiles := []string{".python-version", ".tool-versions", "runtime.txt"}
+	for _, f := range versionFiles {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,225 @@
+package python
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultPythonVersion = "3.13"
+
+// SupportedPythonVersions are the maintained Python releases, newest first, which a
+// requires-python range picks from
+var SupportedPythonVersions = []string{"3.14", "3.13", "3.12", "3.11", "3.10"}
+
+// eolPythonVersions are end-of-life releases that still have images, newest first, only
+// used for constraints no supported release satisfies
+var eolPythonVersions = []string{"3.9", "3.8", "3.7"}
+
+// PythonVersionInfo contains the detected Python version and where it came from
+type PythonVersionInfo struct {
+	Version string
+	// Source is the file, environment variable or rule the version came from
+	Source string
+}
+
+// versionRegex extracts a major.minor Python version from a string
+var versionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// DetectPythonVersion detects the Python version to use
+// Priority:
+// 1. COOLPACK_PYTHON_VERSION environment variable
+// 2. PYTHON_VERSION environment variable
+// 3. .python-version file
+// 4. requires-python in pyproject.toml (or python in [tool.poetry.dependencies])
+// 5. python_version in Pipfile
+// 6. .tool-versions file (asdf)
+// 7. runtime.txt (Heroku)
+// 8. Default to 3.13
+func DetectPythonVersion(ctx *app.Context, project *Project) PythonVersionInfo {
+	// 1. Check COOLPACK_PYTHON_VERSION env var
+	if v := ctx.Env["COOLPACK_PYTHON_VERSION"]; v != "" {
+		return PythonVersionInfo{Version: strings.TrimSpace(v), Source: "COOLPACK_PYTHON_VERSION"}
+	}
+
+	// 2. Check PYTHON_VERSION env var
+	if v := ctx.Env["PYTHON_VERSION"]; v != "" {
+		return PythonVersionInfo{Version: strings.TrimSpace(v), Source: "PYTHON_VERSION"}
+	}
+
+	// 3. Check .python-version file
+	if ctx.HasFile(".python-version") {
+		if data, err := ctx.ReadFile(".python-version"); err == nil {
+			if v := parsePythonVersionFile(string(data)); v != "" {
+				return PythonVersionInfo{Version: v, Source: ".python-version"}
+			}
+		}
+	}
+
+	// 4. Check pyproject.toml
+	if project.PyProject != nil {
+		if constraint := project.PyProject.Project.RequiresPython; constraint != "" {
+			if v := parseVersionConstraint(constraint); v != "" {
+				return PythonVersionInfo{Version: v, Source: fmt.Sprintf("pyproject.toml requires-python (%s)", constraint)}
+			}
+		}
+		if constraint, ok := project.PyProject.Tool.Poetry.Dependencies["python"].(string); ok {
+			if v := parseVersionConstraint(constraint); v != "" {
+				return PythonVersionInfo{Version: v, Source: fmt.Sprintf("pyproject.toml tool.poetry.dependencies.python (%s)", constraint)}
+			}
+		}
+	}
+
+	// 5. Check Pipfile
+	if project.Pipfile != nil {
+		if v := extractMajorMinor(project.Pipfile.Requires.PythonFullVersion); v != "" {
+			return PythonVersionInfo{Version: v, Source: "Pipfile python_full_version"}
+		}
+		if v := extractMajorMinor(project.Pipfile.Requires.PythonVersion); v != "" {
+			return PythonVersionInfo{Version: v, Source: "Pipfile python_version"}
+		}
+	}
+
+	// 6. Check .tool-versions file (asdf format)
+	if v := extractMajorMinor(ctx.ReadToolVersion("python")); v != "" {
+		return PythonVersionInfo{Version: v, Source: ".tool-versions"}
+	}
+
+	// 7. Check runtime.txt (e.g., python-3.11.4)
+	if ctx.HasFile("runtime.txt") {
+		if data, err := ctx.ReadFile("runtime.txt"); err == nil {
+			if v := extractMajorMinor(string(data)); v != "" {
+				return PythonVersionInfo{Version: v, Source: "runtime.txt"}
+			}
+		}
+	}
+
+	// 8. Default
+	return PythonVersionInfo{Version: DefaultPythonVersion, Source: "default"}
+}
+
+// parsePythonVersionFile parses a .python-version file (pyenv format)
+// Only the first line is used; implementation prefixes like "pypy" are ignored
+func parsePythonVersionFile(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return extractMajorMinor(line)
+	}
+	return ""
+}
+
+// specifierRegex matches one version specifier of a PEP 440 specifier set, or of a
+// Poetry constraint (^3.11, ~3.11, 3.11.*)
+var specifierRegex = regexp.MustCompile(`(~=|===?|!=|<=|>=|<|>|\^|~)?\s*(\d+)(?:\.(\d+))?(?:\.(\d+|\*))?`)
+
+// parseVersionConstraint picks a major.minor version for a version constraint
+// Examples: ">=3.10", "~=3.11", ">=3.9,<3.13", "^3.12", "==3.12.*"
+// The default version is used when it satisfies the constraint, then the newest
+// supported one that does, then the newest end-of-life one. Otherwise the lower
+// bound is used
+func parseVersionConstraint(constraint string) string {
+	candidates := append([]string{DefaultPythonVersion}, SupportedPythonVersions...)
+	for _, v := range append(candidates, eolPythonVersions...) {
+		if satisfiesConstraint(v, constraint) {
+			return v
+		}
+	}
+
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "<") || strings.HasPrefix(part, "!=") {
+			continue
+		}
+		if v := extractMajorMinor(part); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// satisfiesConstraint reports whether some release of a major.minor version satisfies
+// a constraint. Poetry alternatives (||) need one of their specifier sets satisfied
+func satisfiesConstraint(version string, constraint string) bool {
+	major, minor := splitVersion(version)
+	for _, alternative := range strings.Split(constraint, "||") {
+		specifiers := specifierRegex.FindAllStringSubmatch(alternative, -1)
+		if len(specifiers) == 0 {
+			continue
+		}
+		matched := true
+		for _, spec := range specifiers {
+			if !satisfiesSpecifier(major, minor, spec) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesSpecifier reports whether some release of major.minor satisfies one specifier,
+// given as the operator, major, minor and patch (or *) matched by specifierRegex
+func satisfiesSpecifier(major, minor int, spec []string) bool {
+	op, patch := spec[1], spec[4]
+	specMajor, _ := strconv.Atoi(spec[2])
+	specMinor, _ := strconv.Atoi(spec[3])
+
+	// Compare major.minor (major only for ">=3"), a positive result meaning the version is newer
+	hasMinor := spec[3] != ""
+	order := major - specMajor
+	if order == 0 && hasMinor {
+		order = minor - specMinor
+	}
+
+	switch op {
+	case ">=":
+		return order >= 0
+	case ">":
+		// 3.8.1 satisfies >3.8
+		return order > 0 || (order == 0 && patch != "" && patch != "*")
+	case "<=":
+		return order <= 0
+	case "<":
+		// Only 3.12.0 doesn't satisfy <3.12.1
+		return order < 0 || (order == 0 && patch != "" && patch != "*" && patch != "0")
+	case "!=":
+		// Other patch releases satisfy an exclusion of one
+		return order != 0 || (hasMinor && patch != "" && patch != "*")
+	case "~=":
+		if patch != "" && patch != "*" {
+			return order == 0
+		}
+		return order >= 0 && major == specMajor
+	case "^":
+		return order >= 0 && major == specMajor
+	default:
+		// ==, ===, ~ and bare versions
+		return order == 0
+	}
+}
+
+// splitVersion returns the major and minor numbers of a major.minor version
+func splitVersion(version string) (int, int) {
+	major, minor, _ := strings.Cut(version, ".")
+	majorNumber, _ := strconv.Atoi(major)
+	minorNumber, _ := strconv.Atoi(minor)
+	return majorNumber, minorNumber
+}
+
+// extractMajorMinor returns the major.minor part of a version string
+func extractMajorMinor(v string) string {
+	matches := versionRegex.FindStringSubmatch(v)
+	if len(matches) > 2 {
+		return matches[1] + "." + matches[2]
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package ruby
+
+import (
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// Framework represents a detected Ruby web framework
+type Framework string
+
+const (
+	FrameworkNone    Framework = ""
+	FrameworkRails   Framework = "rails"
+	FrameworkSinatra Framework = "sinatra"
+	FrameworkHanami  Framework = "hanami"
+	FrameworkRack    Framework = "rack"
+)
+
+// FrameworkInfo contains information about the detected framework
+type FrameworkInfo struct {
+	Name    Framework
+	Version string
+
+	// HasAssetPipeline is true if assets need to be precompiled (Rails sprockets/propshaft, hanami-assets)
+	HasAssetPipeline bool
+
+	// HasPumaConfig is true if config/puma.rb exists
+	HasPumaConfig bool
+}
+
+// gemVersion returns the locked version of a gem, if known
+func gemVersion(lock *GemfileLock, gem string) string {
+	if lock == nil {
+		return ""
+	}
+	return lock.Specs[gem]
+}
+
+// hasGem checks the Gemfile and Gemfile.lock for a gem
+func hasGem(gemfile *Gemfile, lock *GemfileLock, gem string) bool {
+	if gemfile.Gems[gem] {
+		return true
+	}
+	if lock != nil {
+		if _, ok := lock.Specs[gem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectFramework detects the web framework used by the project
+func DetectFramework(ctx *app.Context, gemfile *Gemfile, lock *GemfileLock) FrameworkInfo {
+	info := FrameworkInfo{
+		Name:          FrameworkNone,
+		HasPumaConfig: ctx.HasFile("config/puma.rb"),
+	}
+
+	// Rails: the rails/railties gem plus config/application.rb
+	if (hasGem(gemfile, lock, "rails") || hasGem(gemfile, lock, "railties")) && ctx.HasFile("config/application.rb") {
+		info.Name = FrameworkRails
+		info.Version = gemVersion(lock, "rails")
+		if info.Version == "" {
+			info.Version = gemVersion(lock, "railties")
+		}
+		info.HasAssetPipeline = hasGem(gemfile, lock, "sprockets-rails") || hasGem(gemfile, lock, "propshaft") || hasGem(gemfile, lock, "vite_rails")
+		return info
+	}
+
+	// Hanami
+	if hasGem(gemfile, lock, "hanami") {
+		info.Name = FrameworkHanami
+		info.Version = gemVersion(lock, "hanami")
+		info.HasAssetPipeline = hasGem(gemfile, lock, "hanami-assets")
+		return info
+	}
+
+	// Sinatra
+	if hasGem(gemfile, lock, "sinatra") {
+		info.Name = FrameworkSinatra
+		info.Version = gemVersion(lock, "sinatra")
+		return info
+	}
+
+	// Any other Rack application
+	if ctx.HasFile("config.ru") {
+		info.Name = FrameworkRack
+		info.Version = gemVersion(lock, "rack")
+		return info
+	}
+
+	return info
+}
+
+// GetDefaultBuildCommand returns the default build command for a framework
+func (f FrameworkInfo) GetDefaultBuildCommand() string {
+	switch f.Name {
+	case FrameworkRails:
+		if f.HasAssetPipeline {
+			// Credentials aren't available at build time, so use a throwaway secret
+			return "SECRET_KEY_BASE_DUMMY=1 SECRET_KEY_BASE=precompile_placeholder bundle exec rails assets:precompile"
+		}
+	case FrameworkHanami:
+		if f.HasAssetPipeline {
+			return "bundle exec hanami assets compile"
+		}
+	}
+	return ""
+}
+
+// GetDefaultStartCommand returns the default start command for a framework
+// All commands bind to 0.0.0.0:$PORT
+func (f FrameworkInfo) GetDefaultStartCommand(hasPuma bool) string {
+	switch f.Name {
+	case FrameworkRails, FrameworkHanami:
+		// Rails and Hanami generate a config/puma.rb that reads PORT
+		if f.HasPumaConfig {
+			return "bundle exec puma -C config/puma.rb"
+		}
+		return "bundle exec puma -b tcp://0.0.0.0:$PORT"
+	case FrameworkSinatra, FrameworkRack:
+		if hasPuma {
+			if f.HasPumaConfig {
+				return "bundle exec puma -C config/puma.rb"
+			}
+			return "bundle exec puma -b tcp://0.0.0.0:$PORT"
+		}
+		return "bundle exec rackup --host 0.0.0.0 --port $PORT"
+	}
+	return ""
+}
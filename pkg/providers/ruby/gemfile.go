@@ -0,0 +1,109 @@
+package ruby
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+)
+
+// Gemfile represents the parts of a Gemfile that Coolpack cares about
+type Gemfile struct {
+	// RubyVersion is the version from the ruby directive (e.g., ruby "3.3.0")
+	RubyVersion string
+
+	// RubyVersionFile is set when the ruby directive reads a file (ruby file: ".ruby-version")
+	RubyVersionFile string
+
+	// Gems lists the gems declared in the Gemfile
+	Gems map[string]bool
+}
+
+// GemfileLock represents the parts of Gemfile.lock that Coolpack cares about
+type GemfileLock struct {
+	// Specs maps every resolved gem (direct and transitive) to its version
+	Specs map[string]string
+
+	// RubyVersion is the version from the RUBY VERSION section
+	RubyVersion string
+
+	// BundledWith is the Bundler version that wrote the lock file
+	BundledWith string
+}
+
+var (
+	// gemfileRubyRegex matches ruby "3.3.0" / ruby '~> 3.2'
+	gemfileRubyRegex = regexp.MustCompile(`^ruby\s+["']([^"']+)["']`)
+
+	// gemfileRubyFileRegex matches ruby file: ".ruby-version"
+	gemfileRubyFileRegex = regexp.MustCompile(`^ruby\s+file:\s*["']([^"']+)["']`)
+
+	// gemRegex matches gem "name" declarations
+	gemRegex = regexp.MustCompile(`^gem\s+["']([^"']+)["']`)
+
+	// lockSpecRegex matches a resolved gem in the specs list ("    rails (7.1.3)")
+	lockSpecRegex = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)`)
+
+	// rubyVersionRegex extracts a numeric Ruby version
+	rubyVersionRegex = regexp.MustCompile(`(\d+\.\d+(?:\.\d+)?)`)
+)
+
+// ParseGemfile parses a Gemfile from bytes
+func ParseGemfile(data []byte) *Gemfile {
+	gemfile := &Gemfile{Gems: make(map[string]bool)}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if matches := gemfileRubyFileRegex.FindStringSubmatch(line); len(matches) > 1 {
+			gemfile.RubyVersionFile = matches[1]
+			continue
+		}
+		if matches := gemfileRubyRegex.FindStringSubmatch(line); len(matches) > 1 {
+			if v := rubyVersionRegex.FindString(matches[1]); v != "" {
+				gemfile.RubyVersion = v
+			}
+			continue
+		}
+		if matches := gemRegex.FindStringSubmatch(line); len(matches) > 1 {
+			gemfile.Gems[matches[1]] = true
+		}
+	}
+
+	return gemfile
+}
+
+// ParseGemfileLock parses a Gemfile.lock from bytes
+func ParseGemfileLock(data []byte) *GemfileLock {
+	lock := &GemfileLock{Specs: make(map[string]string)}
+
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Section headers are unindented (GEM, PLATFORMS, RUBY VERSION, BUNDLED WITH, ...)
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = strings.TrimSpace(line)
+			continue
+		}
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+			if matches := lockSpecRegex.FindStringSubmatch(line); len(matches) > 2 {
+				// Strip platform suffixes ("1.16.2-x86_64-linux")
+				lock.Specs[matches[1]] = strings.SplitN(matches[2], "-", 2)[0]
+			}
+		case "RUBY VERSION":
+			if v := rubyVersionRegex.FindString(line); v != "" {
+				lock.RubyVersion = v
+			}
+		case "BUNDLED WITH":
+			if v := strings.TrimSpace(line); v != "" {
+				lock.BundledWith = v
+			}
+		}
+	}
+
+	return lock
+}
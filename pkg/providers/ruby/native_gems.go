@@ -0,0 +1,133 @@
+package ruby
+
+// NativeGem represents a gem with a native extension that needs system libraries
+type NativeGem struct {
+	// Gem is the gem name
+	Gem string
+	// AptPackages are the Debian/Ubuntu packages needed for building
+	AptPackages []string
+	// RuntimePackages are the Debian/Ubuntu packages needed at runtime
+	RuntimePackages []string
+	// Description explains why these packages are needed
+	Description string
+}
+
+// BaseBuildPackages are always installed in the builder, since most Gemfiles
+// contain at least one gem with a C extension (puma, bootsnap, msgpack, ...)
+var BaseBuildPackages = []string{"build-essential", "git", "libyaml-dev", "pkg-config"}
+
+// NativeGems is a list of known gems requiring native system dependencies
+var NativeGems = []NativeGem{
+	{
+		Gem:             "pg",
+		AptPackages:     []string{"libpq-dev"},
+		RuntimePackages: []string{"libpq5"},
+		Description:     "PostgreSQL client",
+	},
+	{
+		Gem:             "mysql2",
+		AptPackages:     []string{"default-libmysqlclient-dev"},
+		RuntimePackages: []string{"libmariadb3"},
+		Description:     "MySQL client",
+	},
+	{
+		Gem:             "trilogy",
+		AptPackages:     []string{"libssl-dev"},
+		RuntimePackages: []string{"libssl3"},
+		Description:     "MySQL client",
+	},
+	{
+		Gem:             "sqlite3",
+		AptPackages:     []string{"libsqlite3-dev"},
+		RuntimePackages: []string{"libsqlite3-0"},
+		Description:     "SQLite database",
+	},
+	{
+		Gem:             "nokogiri",
+		AptPackages:     []string{"libxml2-dev", "libxslt1-dev"},
+		RuntimePackages: []string{"libxml2", "libxslt1.1"},
+		Description:     "XML/HTML parser",
+	},
+	{
+		Gem:             "ruby-vips",
+		RuntimePackages: []string{"libvips42"},
+		Description:     "Image processing (Active Storage variants)",
+	},
+	{
+		Gem:             "rmagick",
+		AptPackages:     []string{"libmagickwand-dev"},
+		RuntimePackages: []string{"imagemagick"},
+		Description:     "ImageMagick bindings",
+	},
+	{
+		Gem:             "mini_magick",
+		RuntimePackages: []string{"imagemagick"},
+		Description:     "ImageMagick CLI wrapper",
+	},
+	{
+		Gem:             "psych",
+		AptPackages:     []string{"libyaml-dev"},
+		RuntimePackages: []string{"libyaml-0-2"},
+		Description:     "YAML parser",
+	},
+	{
+		Gem:             "ffi",
+		AptPackages:     []string{"libffi-dev"},
+		RuntimePackages: []string{"libffi8"},
+		Description:     "Foreign function interface",
+	},
+	{
+		Gem:             "tiny_tds",
+		AptPackages:     []string{"freetds-dev"},
+		RuntimePackages: []string{"freetds-bin"},
+		Description:     "SQL Server client",
+	},
+}
+
+// DetectNativeGems checks which native gems are used by the project
+// Gemfile.lock is preferred since it includes transitive dependencies
+func DetectNativeGems(gemfile *Gemfile, lock *GemfileLock) []NativeGem {
+	var detected []NativeGem
+
+	for _, gem := range NativeGems {
+		if lock != nil {
+			if _, ok := lock.Specs[gem.Gem]; ok {
+				detected = append(detected, gem)
+				continue
+			}
+		}
+		if gemfile.Gems[gem.Gem] {
+			detected = append(detected, gem)
+		}
+	}
+
+	return detected
+}
+
+// GetRequiredAptPackages returns deduplicated build-time and runtime APT packages
+func GetRequiredAptPackages(gems []NativeGem) (build []string, runtime []string) {
+	seenBuild := make(map[string]bool)
+	seenRuntime := make(map[string]bool)
+
+	for _, pkg := range BaseBuildPackages {
+		seenBuild[pkg] = true
+		build = append(build, pkg)
+	}
+
+	for _, gem := range gems {
+		for _, pkg := range gem.AptPackages {
+			if !seenBuild[pkg] {
+				seenBuild[pkg] = true
+				build = append(build, pkg)
+			}
+		}
+		for _, pkg := range gem.RuntimePackages {
+			if !seenRuntime[pkg] {
+				seenRuntime[pkg] = true
+				runtime = append(runtime, pkg)
+			}
+		}
+	}
+
+	return build, runtime
+}
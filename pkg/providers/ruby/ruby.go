@@ -0,0 +1,146 @@
+package ruby
+
+import (
+	"github.com/coollabsio/coolpack/pkg/app"
+	"github.com/coollabsio/coolpack/pkg/providers/node"
+)
+
+// Provider is the Ruby provider implementation
+type Provider struct{}
+
+// New creates a new Ruby provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "ruby"
+}
+
+// Detect checks if the application is a Ruby (Bundler) project
+// Rails and Hanami apps often ship a package.json for their assets, so a Rack
+// entry point ranks as an application. A Gemfile next to package.json without
+// one is only a weak match, since JavaScript projects use Gemfiles for tooling
+// (CocoaPods, fastlane)
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("Gemfile") {
+		return app.NoMatch(), nil
+	}
+	if ctx.HasFile("config.ru") {
+		return app.Detected(app.ConfidenceApp, "found Gemfile", "config.ru is a Rack entry point"), nil
+	}
+	if ctx.HasFile("package.json") {
+		return app.Detected(app.ConfidenceWeak, "found Gemfile", "no config.ru next to package.json"), nil
+	}
+	return app.Detected(app.ConfidenceManifest, "found Gemfile"), nil
+}
+
+// Plan generates a build plan for the Ruby application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	gemfile := &Gemfile{Gems: make(map[string]bool)}
+	if data, err := ctx.ReadFile("Gemfile"); err == nil {
+		gemfile = ParseGemfile(data)
+	}
+
+	var lock *GemfileLock
+	if data, err := ctx.ReadFile("Gemfile.lock"); err == nil {
+		lock = ParseGemfileLock(data)
+	}
+
+	// Detect Ruby version
+	rubyVersion := DetectRubyVersion(ctx, gemfile, lock)
+
+	// Detect framework
+	fwInfo := DetectFramework(ctx, gemfile, lock)
+
+	plan := &app.Plan{
+		Provider:        "ruby",
+		Language:        "ruby",
+		LanguageVersion: rubyVersion,
+		PackageManager:  "bundler",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+	if lock != nil {
+		plan.PackageManagerVersion = lock.BundledWith
+	}
+
+	// Add framework info
+	if fwInfo.Name != FrameworkNone {
+		plan.Framework = string(fwInfo.Name)
+		plan.FrameworkVersion = fwInfo.Version
+	}
+
+	plan.InstallCommand = "bundle install"
+	plan.BuildCommand = fwInfo.GetDefaultBuildCommand()
+	plan.StartCommand = determineStartCommand(ctx, fwInfo, hasGem(gemfile, lock, "puma"))
+
+	// Native gems need system libraries to compile and to load at runtime
+	nativeGems := DetectNativeGems(gemfile, lock)
+	buildPackages, runtimePackages := GetRequiredAptPackages(nativeGems)
+	plan.Packages.Apt = buildPackages
+	if len(runtimePackages) > 0 {
+		plan.Packages.RuntimeApt = runtimePackages
+	}
+	if len(nativeGems) > 0 {
+		var detected []string
+		for _, gem := range nativeGems {
+			detected = append(detected, gem.Gem)
+		}
+		plan.Packages.Native = detected
+	}
+
+	// Asset pipelines backed by package.json (jsbundling-rails, vite_ruby, hanami-assets)
+	// need Node.js in the builder; assets:precompile runs the JavaScript install itself
+	if fwInfo.HasAssetPipeline && ctx.HasFile("package.json") {
+		var pkg *node.PackageJSON
+		if data, err := ctx.ReadFile("package.json"); err == nil {
+			pkg, _ = node.ParsePackageJSON(data)
+		}
+		if pkg == nil {
+			pkg = &node.PackageJSON{}
+		}
+		nodeVersion := node.DetectNodeVersion(ctx, pkg)
+		plan.Tools.NodeVersion = nodeVersion.Version
+		plan.SetSource("tools.node_version", nodeVersion.Source)
+		plan.Tools.NodePackageManager = string(node.DetectPackageManager(ctx, pkg).Name)
+	}
+
+	// Ruby apps always run behind an application server
+	plan.Runtime.OutputType = "server"
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// determineStartCommand determines the start command to use
+func determineStartCommand(ctx *app.Context, fw FrameworkInfo, hasPuma bool) string {
+	// Use framework-specific defaults
+	if cmd := fw.GetDefaultStartCommand(hasPuma); cmd != "" {
+		return cmd
+	}
+
+	// Check for common entry points
+	for _, ep := range []string{"app.rb", "main.rb", "server.rb"} {
+		if ctx.HasFile(ep) {
+			return "bundle exec ruby " + ep
+		}
+	}
+
+	return ""
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range []string{"Gemfile", "Gemfile.lock", "config.ru", "config/application.rb", "config/puma.rb", ".ruby-version", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
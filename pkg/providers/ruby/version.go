@@ -0,0 +1,55 @@
+package ruby
+
+import (
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+const DefaultRubyVersion = "3.4"
+
+// DetectRubyVersion detects the Ruby version to use
+// Priority:
+// 1. COOLPACK_RUBY_VERSION environment variable
+// 2. .ruby-version file (or the file referenced by ruby file: in the Gemfile)
+// 3. ruby directive in the Gemfile
+// 4. RUBY VERSION in Gemfile.lock
+// 5. .tool-versions file (asdf)
+// 6. Default to 3.4
+func DetectRubyVersion(ctx *app.Context, gemfile *Gemfile, lock *GemfileLock) string {
+	// 1. Check COOLPACK_RUBY_VERSION env var
+	if v := ctx.Env["COOLPACK_RUBY_VERSION"]; v != "" {
+		return strings.TrimSpace(v)
+	}
+
+	// 2. Check .ruby-version file
+	versionFile := ".ruby-version"
+	if gemfile.RubyVersionFile != "" {
+		versionFile = gemfile.RubyVersionFile
+	}
+	if ctx.HasFile(versionFile) {
+		if data, err := ctx.ReadFile(versionFile); err == nil {
+			if v := rubyVersionRegex.FindString(string(data)); v != "" {
+				return v
+			}
+		}
+	}
+
+	// 3. Check ruby directive in the Gemfile
+	if gemfile.RubyVersion != "" {
+		return gemfile.RubyVersion
+	}
+
+	// 4. Check Gemfile.lock
+	if lock != nil && lock.RubyVersion != "" {
+		return lock.RubyVersion
+	}
+
+	// 5. Check .tool-versions file (asdf format)
+	if v := rubyVersionRegex.FindString(ctx.ReadToolVersion("ruby")); v != "" {
+		return v
+	}
+
+	// 6. Default
+	return DefaultRubyVersion
+}
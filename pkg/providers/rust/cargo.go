@@ -0,0 +1,209 @@
+package rust
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// CargoManifest represents the parts of Cargo.toml that Coolpack cares about
+// Fields a package can inherit from the workspace (version.workspace = true) are
+// tables instead of strings, so none of them is decoded
+type CargoManifest struct {
+	Package *struct {
+		Name       string `toml:"name"`
+		DefaultRun string `toml:"default-run"`
+		Autobins   *bool  `toml:"autobins"`
+	} `toml:"package"`
+	Bin []struct {
+		Name string `toml:"name"`
+		Path string `toml:"path"`
+	} `toml:"bin"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+// Binary is a binary target of a package
+type Binary struct {
+	// Name is the binary name (cargo build --bin <name>)
+	Name string
+
+	// Package is the package that defines the binary
+	Package string
+}
+
+// ParseCargoManifest parses a Cargo.toml file from bytes
+func ParseCargoManifest(data []byte) (*CargoManifest, error) {
+	var manifest CargoManifest
+	if _, err := toml.Decode(string(data), &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// loadManifest reads and parses the Cargo.toml in the given directory
+func loadManifest(ctx *app.Context, dir string) (*CargoManifest, error) {
+	data, err := ctx.ReadFile(path.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseCargoManifest(data)
+}
+
+// DetectBinaries returns all binary targets of the root package and workspace members
+func DetectBinaries(ctx *app.Context, root *CargoManifest) ([]Binary, error) {
+	var binaries []Binary
+
+	if root.Package != nil {
+		binaries = append(binaries, packageBinaries(ctx, ".", root)...)
+	}
+
+	if root.Workspace != nil {
+		for _, dir := range workspaceMembers(ctx, root) {
+			member, err := loadManifest(ctx, dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path.Join(dir, "Cargo.toml"), err)
+			}
+			// Nested virtual manifests have no targets
+			if member.Package == nil {
+				continue
+			}
+			binaries = append(binaries, packageBinaries(ctx, dir, member)...)
+		}
+	}
+
+	return binaries, nil
+}
+
+// packageBinaries returns the binary targets of a single package:
+// explicit [[bin]] entries, src/main.rs and src/bin/*.rs (cargo auto-discovery)
+// Like cargo, files an explicit [[bin]] already builds aren't discovered again,
+// and autobins = false turns discovery off
+func packageBinaries(ctx *app.Context, dir string, manifest *CargoManifest) []Binary {
+	pkgName := manifest.Package.Name
+	seen := make(map[string]bool)
+	var binaries []Binary
+
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			binaries = append(binaries, Binary{Name: name, Package: pkgName})
+		}
+	}
+
+	// Source files of the explicit targets, relative to the package
+	explicit := make(map[string]bool)
+	for _, bin := range manifest.Bin {
+		name := bin.Name
+		if name == "" {
+			name = pkgName
+		}
+		add(name)
+
+		switch {
+		case bin.Path != "":
+			explicit[path.Clean(bin.Path)] = true
+		case name == pkgName:
+			explicit["src/main.rs"] = true
+		default:
+			explicit["src/bin/"+name+".rs"] = true
+			explicit["src/bin/"+name+"/main.rs"] = true
+		}
+	}
+
+	if autobins := manifest.Package.Autobins; autobins != nil && !*autobins {
+		return binaries
+	}
+
+	// discover adds an inferred target unless an explicit one builds its file
+	discover := func(name, file string) {
+		if !explicit[file] {
+			add(name)
+		}
+	}
+
+	if ctx.HasFile(path.Join(dir, "src/main.rs")) {
+		discover(pkgName, "src/main.rs")
+	}
+
+	if files, err := ctx.ListFiles(path.Join(dir, "src/bin/*.rs")); err == nil {
+		for _, f := range files {
+			name := strings.TrimSuffix(path.Base(f), ".rs")
+			discover(name, "src/bin/"+path.Base(f))
+		}
+	}
+	if files, err := ctx.ListFiles(path.Join(dir, "src/bin/*/main.rs")); err == nil {
+		for _, f := range files {
+			name := path.Base(path.Dir(f))
+			discover(name, "src/bin/"+name+"/main.rs")
+		}
+	}
+
+	return binaries
+}
+
+// workspaceMembers expands [workspace] members globs into package directories
+func workspaceMembers(ctx *app.Context, root *CargoManifest) []string {
+	excluded := make(map[string]bool)
+	for _, e := range root.Workspace.Exclude {
+		excluded[path.Clean(e)] = true
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, member := range root.Workspace.Members {
+		matches, err := ctx.ListFiles(path.Join(member, "Cargo.toml"))
+		if err != nil {
+			continue
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			dir := path.Dir(m)
+			if dir == "." || excluded[dir] || seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// SelectBinary picks the binary to build when none was requested
+// Priority:
+// 1. default-run of the root package
+// 2. The binary named after the root package
+// 3. The first binary (workspace members in declaration order)
+func SelectBinary(binaries []Binary, root *CargoManifest) Binary {
+	if len(binaries) == 0 {
+		return Binary{}
+	}
+
+	if root.Package != nil {
+		if root.Package.DefaultRun != "" {
+			for _, bin := range binaries {
+				if bin.Name == root.Package.DefaultRun {
+					return bin
+				}
+			}
+		}
+		for _, bin := range binaries {
+			if bin.Name == root.Package.Name {
+				return bin
+			}
+		}
+	}
+
+	return binaries[0]
+}
+
+// HasLockedCrate checks if a crate appears in Cargo.lock
+func HasLockedCrate(lock []byte, crate string) bool {
+	return strings.Contains(string(lock), "name = \""+crate+"\"\n")
+}
@@ -0,0 +1,177 @@
+package rust
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// BinaryPath is where the release binary is placed in the image
+const BinaryPath = "/app/server"
+
+// Provider is the Rust provider implementation
+type Provider struct{}
+
+// New creates a new Rust provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "rust"
+}
+
+// Detect checks if the application is a Cargo project
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	if !ctx.HasFile("Cargo.toml") {
+		return app.NoMatch(), nil
+	}
+	detection := app.Detected(app.ConfidenceManifest, "found Cargo.toml")
+
+	root, err := loadManifest(ctx, ".")
+	if err != nil {
+		return detection, fmt.Errorf("failed to parse Cargo.toml: %w", err)
+	}
+	binaries, err := DetectBinaries(ctx, root)
+	if err != nil {
+		return detection, err
+	}
+	if len(binaries) > 0 {
+		detection.Raise(app.ConfidenceApp, "binary target "+binaries[0].Name)
+	}
+	return detection, nil
+}
+
+// Plan generates a build plan for the Rust application
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	root, err := loadManifest(ctx, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Cargo.toml: %w", err)
+	}
+
+	// Detect toolchain version
+	rustVersion := DetectRustVersion(ctx)
+
+	// Resolve binary targets (including workspace members)
+	binaries, err := DetectBinaries(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(binaries) == 0 {
+		return nil, fmt.Errorf("no binary target found (looked for src/main.rs, src/bin/ and [[bin]] entries)")
+	}
+
+	// Select the binary to build (COOLPACK_RUST_BIN > auto-detected)
+	binary := SelectBinary(binaries, root)
+	if requested := ctx.Env["COOLPACK_RUST_BIN"]; requested != "" {
+		binary = Binary{}
+		var names []string
+		for _, bin := range binaries {
+			names = append(names, bin.Name)
+			if bin.Name == requested {
+				binary = bin
+			}
+		}
+		if binary.Name == "" {
+			return nil, fmt.Errorf("binary %s not found (available: %s)", requested, strings.Join(names, ", "))
+		}
+	}
+
+	plan := &app.Plan{
+		Provider:        "rust",
+		Language:        "rust",
+		LanguageVersion: rustVersion,
+		PackageManager:  "cargo",
+		DetectedFiles:   detectRelevantFiles(ctx),
+	}
+
+	// Build only the selected binary in release mode
+	locked := ""
+	if ctx.HasFile("Cargo.lock") {
+		locked = " --locked"
+	}
+	plan.BuildCommand = fmt.Sprintf("cargo build --release%s --package %s --bin %s", locked, binary.Package, binary.Name)
+	plan.StartCommand = BinaryPath
+
+	plan.Runtime.OutputType = "server"
+	plan.Rust.Binary = binary.Name
+	if len(binaries) > 1 {
+		var names []string
+		for _, bin := range binaries {
+			names = append(names, bin.Name)
+		}
+		plan.Rust.Binaries = names
+	}
+	if root.Workspace != nil {
+		plan.Rust.IsWorkspace = true
+	}
+	if root.Package != nil && root.Package.Name != "" {
+		plan.Project.Name = root.Package.Name
+	}
+
+	// System libraries needed by common -sys crates
+	if lock, err := ctx.ReadFile("Cargo.lock"); err == nil {
+		buildPackages, runtimePackages := detectSystemPackages(lock)
+		if len(buildPackages) > 0 {
+			plan.Packages.Apt = buildPackages
+		}
+		if len(runtimePackages) > 0 {
+			plan.Packages.RuntimeApt = runtimePackages
+		}
+	}
+
+	// Check for base image override
+	if baseImage := ctx.Env["COOLPACK_BASE_IMAGE"]; baseImage != "" {
+		plan.Runtime.BaseImage = baseImage
+	}
+
+	return plan, nil
+}
+
+// sysCrates maps -sys crates to the APT packages needed to build and run them
+var sysCrates = []struct {
+	Crate   string
+	Build   []string
+	Runtime []string
+}{
+	{Crate: "openssl-sys", Build: []string{"pkg-config", "libssl-dev"}, Runtime: []string{"libssl3"}},
+	{Crate: "pq-sys", Build: []string{"libpq-dev"}, Runtime: []string{"libpq5"}},
+	{Crate: "mysqlclient-sys", Build: []string{"pkg-config", "default-libmysqlclient-dev"}, Runtime: []string{"libmariadb3"}},
+	{Crate: "libsqlite3-sys", Build: []string{"pkg-config", "libsqlite3-dev"}, Runtime: []string{"libsqlite3-0"}},
+}
+
+// detectSystemPackages returns build-time and runtime APT packages for -sys crates in Cargo.lock
+func detectSystemPackages(lock []byte) (build []string, runtime []string) {
+	seen := make(map[string]bool)
+	for _, sys := range sysCrates {
+		if !HasLockedCrate(lock, sys.Crate) {
+			continue
+		}
+		for _, pkg := range sys.Build {
+			if !seen[pkg] {
+				seen[pkg] = true
+				build = append(build, pkg)
+			}
+		}
+		for _, pkg := range sys.Runtime {
+			if !seen[pkg] {
+				seen[pkg] = true
+				runtime = append(runtime, pkg)
+			}
+		}
+	}
+	return build, runtime
+}
+
+// detectRelevantFiles returns a list of relevant files that were detected
+func detectRelevantFiles(ctx *app.Context) []string {
+	var files []string
+	for _, f := range []string{"Cargo.toml", "Cargo.lock", "rust-toolchain.toml", "rust-toolchain", ".tool-versions"} {
+		if ctx.HasFile(f) {
+			files = append(files, f)
+		}
+	}
+	return files
+}
@@ -0,0 +1,78 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// DefaultRustVersion is the latest stable release (rust:1 image tag)
+const DefaultRustVersion = "1"
+
+// DetectRustVersion detects the Rust toolchain used for the builder image
+// Priority:
+// 1. COOLPACK_RUST_VERSION environment variable
+// 2. rust-toolchain.toml / rust-toolchain file
+// 3. .tool-versions file (asdf)
+// 4. Default to the latest stable release
+func DetectRustVersion(ctx *app.Context) string {
+	// 1. Check COOLPACK_RUST_VERSION env var
+	if v := ctx.Env["COOLPACK_RUST_VERSION"]; v != "" {
+		return normalizeChannel(v)
+	}
+
+	// 2. Check rust-toolchain.toml (or the TOML-formatted legacy rust-toolchain file)
+	for _, f := range []string{"rust-toolchain.toml", "rust-toolchain"} {
+		if !ctx.HasFile(f) {
+			continue
+		}
+		data, err := ctx.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		if v := parseToolchainFile(string(data)); v != "" {
+			return normalizeChannel(v)
+		}
+	}
+
+	// 3. Check .tool-versions file (asdf format)
+	if v := ctx.ReadToolVersion("rust"); v != "" {
+		return normalizeChannel(v)
+	}
+
+	// 4. Default
+	return DefaultRustVersion
+}
+
+// parseToolchainFile reads the channel from a rust-toolchain(.toml) file
+// The legacy format is a single line containing only the channel
+func parseToolchainFile(content string) string {
+	var toolchain struct {
+		Toolchain struct {
+			Channel string `toml:"channel"`
+		} `toml:"toolchain"`
+	}
+	if _, err := toml.Decode(content, &toolchain); err == nil && toolchain.Toolchain.Channel != "" {
+		return toolchain.Toolchain.Channel
+	}
+
+	line := strings.TrimSpace(strings.Split(content, "\n")[0])
+	if line != "" && !strings.HasPrefix(line, "[") {
+		return line
+	}
+	return ""
+}
+
+// normalizeChannel converts a toolchain channel into an image version
+// "stable" -> "1", "1.75.0" -> "1.75.0", "nightly-2024-01-01" -> "nightly"
+func normalizeChannel(channel string) string {
+	channel = strings.TrimSpace(channel)
+	switch {
+	case channel == "stable":
+		return DefaultRustVersion
+	case strings.HasPrefix(channel, "nightly"):
+		return "nightly"
+	}
+	return channel
+}
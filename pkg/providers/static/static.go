@@ -0,0 +1,64 @@
+package static
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/coollabsio/coolpack/pkg/app"
+)
+
+// siteDirs are the directories searched for index.html, in priority order
+// public/ wins so the rest of the repository isn't published
+var siteDirs = []string{"public", "."}
+
+// Provider is the plain static site provider implementation
+type Provider struct{}
+
+// New creates a new static site provider
+func New() *Provider {
+	return &Provider{}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	return "static"
+}
+
+// Detect checks if the application is a plain static site (index.html in the root or public/)
+// Any project can have an index.html, so it's always a weak match
+func (p *Provider) Detect(ctx *app.Context) (app.Detection, error) {
+	index := findIndex(ctx)
+	if index == "" {
+		return app.NoMatch(), nil
+	}
+	return app.Detected(app.ConfidenceWeak, "found "+index), nil
+}
+
+// Plan generates a build plan for the static site
+func (p *Provider) Plan(ctx *app.Context) (*app.Plan, error) {
+	index := findIndex(ctx)
+	if index == "" {
+		return nil, fmt.Errorf("no index.html found in the project root or public/")
+	}
+	plan := &app.Plan{
+		Provider:      "static",
+		Language:      "static",
+		DetectedFiles: []string{index},
+	}
+
+	// Files are served as-is, there is nothing to install or build
+	plan.Runtime.OutputType = "static"
+	plan.Static.SiteDir = path.Dir(index)
+
+	return plan, nil
+}
+
+// findIndex returns the path of the site's index.html, or "" if there is none
+func findIndex(ctx *app.Context) string {
+	for _, dir := range siteDirs {
+		if index := path.Join(dir, "index.html"); ctx.HasFile(index) {
+			return index
+		}
+	}
+	return ""
+}